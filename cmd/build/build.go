@@ -0,0 +1,151 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/build"
+	"github.com/ajalab/slom/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+func run(projectFileName string, force bool, stdout io.Writer) error {
+	builder, err := build.LoadProject(projectFileName, force)
+	if err != nil {
+		return err
+	}
+
+	results, err := builder.Build()
+	if printErr := printResults(stdout, results, true); printErr != nil {
+		return printErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if numFailed := countFailed(results); numFailed > 0 {
+		return fmt.Errorf("failed to build %d output(s)", numFailed)
+	}
+	return nil
+}
+
+func runWatch(
+	ctx context.Context,
+	projectFileName string,
+	force bool,
+	interval time.Duration,
+	stdout io.Writer,
+) error {
+	rebuild := func() error {
+		builder, err := build.LoadProject(projectFileName, false)
+		if err != nil {
+			_, err = fmt.Fprintf(stdout, "%s\n", err)
+			return err
+		}
+		results, err := builder.Build()
+		if printErr := printResults(stdout, results, false); printErr != nil {
+			return printErr
+		}
+		if err != nil {
+			_, err = fmt.Fprintf(stdout, "%s\n", err)
+			return err
+		}
+		return nil
+	}
+
+	if err := run(projectFileName, force, stdout); err != nil {
+		fmt.Fprintf(stdout, "%s\n", err)
+	}
+	fmt.Fprintf(stdout, "watching %s for changes\n", projectFileName)
+
+	w := watch.NewWatcher(interval, func() []string {
+		files := []string{projectFileName}
+		builder, err := build.LoadProject(projectFileName, false)
+		if err != nil {
+			return files
+		}
+		// Outputs are excluded so that the files written by a build don't trigger another build.
+		outputs := make(map[string]struct{})
+		for _, output := range builder.Outputs() {
+			outputs[output] = struct{}{}
+		}
+		for _, input := range builder.Inputs() {
+			if _, ok := outputs[input]; !ok {
+				files = append(files, input)
+			}
+		}
+		return files
+	})
+	err := w.Watch(ctx, rebuild)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func printResults(w io.Writer, results []*build.Result, verbose bool) error {
+	for _, r := range results {
+		if r.Status == build.StatusSkipped && !verbose {
+			continue
+		}
+
+		var err error
+		switch {
+		case r.Status == build.StatusFailed && r.Output == "":
+			_, err = fmt.Fprintf(w, "%s\t%s: %s\n", r.Status, r.Target, r.Err)
+		case r.Status == build.StatusFailed:
+			_, err = fmt.Fprintf(w, "%s\t%s: %s: %s\n", r.Status, r.Target, r.Input, r.Err)
+		default:
+			_, err = fmt.Fprintf(w, "%s\t%s: %s -> %s\n", r.Status, r.Target, r.Input, r.Output)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func countFailed(results []*build.Result) int {
+	var numFailed int
+	for _, r := range results {
+		if r.Status == build.StatusFailed {
+			numFailed++
+		}
+	}
+	return numFailed
+}
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var projectFileName string
+	var force bool
+	var watch bool
+	var watchInterval time.Duration
+
+	command := &cobra.Command{
+		Use:   "build [-f projectFile] [--force] [--watch]",
+		Short: "Build all the targets in a project",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if watch {
+				if watchInterval <= 0 {
+					return fmt.Errorf("--watch-interval must be positive")
+				}
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+				defer stop()
+				return runWatch(ctx, projectFileName, force, watchInterval, cmd.OutOrStdout())
+			}
+			return run(projectFileName, force, cmd.OutOrStdout())
+		},
+	}
+	command.Flags().StringVarP(&projectFileName, "file", "f", "slom.yaml", "path to the project file")
+	command.Flags().BoolVar(&force, "force", false, "build all the outputs even if their inputs haven't changed since the last build")
+	command.Flags().BoolVarP(&watch, "watch", "w", false, "keep running and rebuild the affected outputs when the project file or inputs change")
+	command.Flags().DurationVar(&watchInterval, "watch-interval", time.Second, "interval to check changes of files in watch mode")
+
+	return command
+}
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+// SpecFlags are flags to load a spec file with overlays and variables.
+type SpecFlags struct {
+	Overlays []string
+	Vars     []string
+}
+
+// AddFlags adds the flags to command.
+func (f *SpecFlags) AddFlags(command *cobra.Command) {
+	command.Flags().StringArrayVar(&f.Overlays, "overlay", nil, "spec file applied to the spec file as an overlay. Can be specified multiple times and applied in order")
+	command.Flags().StringArrayVar(&f.Vars, "var", nil, "value of a variable substituted for ${NAME} in the spec file in the form of NAME=VALUE. Variables not given are looked up from the environment variables")
+}
+
+// SpecOptions returns the options to load a spec file specified by the flags.
+func (f *SpecFlags) SpecOptions() (*generate.SpecOptions, error) {
+	vars := make(map[string]string)
+	for _, v := range f.Vars {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("variable \"%s\" must be in the form of NAME=VALUE", v)
+		}
+		vars[name] = value
+	}
+	return &generate.SpecOptions{
+		Overlays: f.Overlays,
+		Vars:     vars,
+	}, nil
+}
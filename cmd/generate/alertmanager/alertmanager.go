@@ -0,0 +1,48 @@
+package alertmanager
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/alertmanager"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var output string
+	var defaultReceiver string
+	var severityLabel string
+	var receivers map[string]string
+	var specFlags common.SpecFlags
+
+	command := &cobra.Command{
+		Use:   "alertmanager [-o output] [--default-receiver receiver] [--receiver severity=receiver]... [--overlay file]... [--var NAME=VALUE]... specFileName",
+		Short: "Generate an Alertmanager configuration fragment for the alerts of a spec",
+		Long: `Generate an Alertmanager configuration fragment for the alerts of a spec.
+
+The fragment has a route subtree and inhibit rules that can be merged into an Alertmanager configuration.
+The route matches the alerts of the spec, groups them by SLO, and routes them to receivers by their severities.
+Among the burn rate alerts of an SLO, an alert with a higher burn rate threshold inhibits alerts with lower thresholds
+for the same SLO.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specOptions, err := specFlags.SpecOptions()
+			if err != nil {
+				return err
+			}
+			return generate.Alertmanager(cmd.OutOrStdout(), args[0], &generate.AlertmanagerOptions{
+				Output:          output,
+				DefaultReceiver: defaultReceiver,
+				SeverityLabel:   severityLabel,
+				Receivers:       receivers,
+				Spec:            *specOptions,
+			})
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "yaml", "output format of the generated configuration. Either \"yaml\", \"json\", \"go-template-file=<filename>\", \"go-template-dir=<dirname>\"")
+	command.Flags().StringVar(&defaultReceiver, "default-receiver", "", "receiver of the route for the alerts of the spec")
+	command.Flags().StringVar(&severityLabel, "severity-label", alertmanager.DefaultSeverityLabel, "label name that tells the severity of alerts")
+	command.Flags().StringToStringVar(&receivers, "receiver", nil, "receiver for alerts with a severity in the form of severity=receiver")
+	specFlags.AddFlags(command)
+
+	return command
+}
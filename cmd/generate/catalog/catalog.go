@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/document"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var output string
+	var groupBy []string
+	var specFlags common.SpecFlags
+
+	command := &cobra.Command{
+		Use:   "catalog [-o output] [--group-by labels] [--overlay file]... [--var NAME=VALUE]... specFileName...",
+		Short: "Generate an SLO catalog from multiple specs",
+		Long: `Generate an SLO catalog from multiple specs.
+
+The catalog has an index of specs grouped by spec labels, summaries of objectives and windows and links to each SLO.
+If a directory is given, spec files (*.yaml and *.yml) directly under it are loaded.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specOptions, err := specFlags.SpecOptions()
+			if err != nil {
+				return err
+			}
+			return generate.Catalog(cmd.OutOrStdout(), args, &generate.CatalogOptions{
+				Output:  output,
+				GroupBy: groupBy,
+				Spec:    *specOptions,
+			})
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "json", "output format of the generated catalog. Either \"json\", \"yaml\", \"markdown\", \"html\", \"go-template-file=<filename>\", \"go-template-dir=<dirname>\"")
+	command.Flags().StringSliceVar(&groupBy, "group-by", document.DefaultCatalogGroupBy, "spec label names to group specs by")
+	specFlags.AddFlags(command)
+
+	return command
+}
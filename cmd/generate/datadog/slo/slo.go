@@ -0,0 +1,40 @@
+package slo
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var output string
+	var specFlags common.SpecFlags
+
+	command := &cobra.Command{
+		Use:   "datadog-slo [-o output] [--overlay file]... [--var NAME=VALUE]... specFileName",
+		Short: "Generate Datadog SLOs and SLO monitors",
+		Long: `Generate Datadog SLOs and SLO monitors.
+
+The output is a list of metric-based SLOs in the Datadog SLO API, each with the monitors for its alerts
+in the Datadog Monitor API. Only SLOs with a Datadog indicator and a rolling objective window of 7d, 30d or 90d are supported.
+
+Burn rate alerts are mapped onto burn_rate() monitors and error budget alerts onto error_budget() monitors.
+The queries of the monitors refer to the SLO with the placeholder "${slo_id}",
+which must be replaced with the ID assigned by Datadog after the SLO is created.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specOptions, err := specFlags.SpecOptions()
+			if err != nil {
+				return err
+			}
+			return generate.DatadogSLO(cmd.OutOrStdout(), args[0], &generate.DatadogSLOOptions{
+				Output: output,
+				Spec:   *specOptions,
+			})
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "json", "output format of the generated SLOs. Either \"json\", \"yaml\", \"go-template-file=<filename>\", \"go-template-dir=<dirname>\"")
+	specFlags.AddFlags(command)
+
+	return command
+}
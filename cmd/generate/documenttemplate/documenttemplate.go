@@ -0,0 +1,28 @@
+package documenttemplate
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/document"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var output string
+
+	command := &cobra.Command{
+		Use:   "document-template [-o output]",
+		Short: "Generate a built-in SLO document template as a starting point of custom templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := document.Template(output)
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(content)
+			return err
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "markdown", "output format of the built-in template. Either \"markdown\" or \"html\"")
+
+	return command
+}
@@ -2,7 +2,14 @@ package generate
 
 import (
 	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/cmd/generate/alertmanager"
+	"github.com/ajalab/slom/cmd/generate/catalog"
+	datadogslo "github.com/ajalab/slom/cmd/generate/datadog/slo"
 	"github.com/ajalab/slom/cmd/generate/document"
+	"github.com/ajalab/slom/cmd/generate/documenttemplate"
+	googlecloudslo "github.com/ajalab/slom/cmd/generate/googlecloud/slo"
+	grafanadashboard "github.com/ajalab/slom/cmd/generate/grafana/dashboard"
+	grafanaslo "github.com/ajalab/slom/cmd/generate/grafana/slo"
 	"github.com/ajalab/slom/cmd/generate/prometheus/rule"
 	"github.com/ajalab/slom/cmd/generate/prometheus/series"
 	"github.com/ajalab/slom/cmd/generate/prometheus/tsdb"
@@ -17,7 +24,14 @@ func NewCommand(flags *common.CommonFlags) *cobra.Command {
 	command.AddCommand(rule.NewCommand(flags))
 	command.AddCommand(series.NewCommand(flags))
 	command.AddCommand(tsdb.NewCommand(flags))
+	command.AddCommand(catalog.NewCommand(flags))
 	command.AddCommand(document.NewCommand(flags))
+	command.AddCommand(documenttemplate.NewCommand(flags))
+	command.AddCommand(grafanadashboard.NewCommand(flags))
+	command.AddCommand(grafanaslo.NewCommand(flags))
+	command.AddCommand(datadogslo.NewCommand(flags))
+	command.AddCommand(googlecloudslo.NewCommand(flags))
+	command.AddCommand(alertmanager.NewCommand(flags))
 
 	return command
 }
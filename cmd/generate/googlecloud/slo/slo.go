@@ -0,0 +1,39 @@
+package slo
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var output string
+	var specFlags common.SpecFlags
+
+	command := &cobra.Command{
+		Use:   "google-cloud-monitoring-slo [-o output] [--overlay file]... [--var NAME=VALUE]... specFileName",
+		Short: "Generate Google Cloud Monitoring SLOs and alert policies",
+		Long: `Generate Google Cloud Monitoring SLOs and alert policies.
+
+The output is a list of ServiceLevelObjective resources, each with the ID and the parent service to create it with
+and the AlertPolicy resources for its alerts. Only SLOs with a Google Cloud Monitoring indicator are supported.
+
+Burn rate alerts are mapped onto alert policies on select_slo_burn_rate() with a condition for each window,
+and error budget alerts onto alert policies on select_slo_budget_fraction().`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specOptions, err := specFlags.SpecOptions()
+			if err != nil {
+				return err
+			}
+			return generate.GoogleCloudMonitoringSLO(cmd.OutOrStdout(), args[0], &generate.GoogleCloudMonitoringSLOOptions{
+				Output: output,
+				Spec:   *specOptions,
+			})
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "json", "output format of the generated SLOs. Either \"json\", \"yaml\", \"go-template-file=<filename>\", \"go-template-dir=<dirname>\"")
+	specFlags.AddFlags(command)
+
+	return command
+}
@@ -0,0 +1,42 @@
+package dashboard
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var output string
+	var shareRules bool
+	var aggregateWindows bool
+	var specFlags common.SpecFlags
+
+	command := &cobra.Command{
+		Use:   "grafana-dashboard [-o output] [--share-rules] [--aggregate-windows] [--overlay file]... [--var NAME=VALUE]... specFileName",
+		Short: "Generate a Grafana dashboard for the series recorded by Prometheus rules",
+		Long: `Generate a Grafana dashboard for the series recorded by Prometheus rules.
+
+The dashboard JSON can be imported into Grafana. The Prometheus data source is chosen by a template variable on the dashboard.
+If the rules are generated with --share-rules or --aggregate-windows, the same flags must be given so that the dashboard selects the recorded series.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specOptions, err := specFlags.SpecOptions()
+			if err != nil {
+				return err
+			}
+			return generate.GrafanaDashboard(cmd.OutOrStdout(), args[0], &generate.GrafanaDashboardOptions{
+				Output:           output,
+				ShareRules:       shareRules,
+				AggregateWindows: aggregateWindows,
+				Spec:             *specOptions,
+			})
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "json", "output format of the generated dashboard. Either \"json\", \"yaml\", \"go-template-file=<filename>\", \"go-template-dir=<dirname>\"")
+	command.Flags().BoolVar(&shareRules, "share-rules", false, "select the series recorded once for all SLOs with the same indicator by rules generated with --share-rules")
+	command.Flags().BoolVar(&aggregateWindows, "aggregate-windows", false, "select the series recorded by rules generated with --aggregate-windows")
+	specFlags.AddFlags(command)
+
+	return command
+}
@@ -0,0 +1,43 @@
+package slo
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var output string
+	var destinationDatasourceUID string
+	var specFlags common.SpecFlags
+
+	command := &cobra.Command{
+		Use:   "grafana-slo [-o output] [--destination-datasource-uid uid] [--overlay file]... [--var NAME=VALUE]... specFileName",
+		Short: "Generate SLOs for Grafana SLO",
+		Long: `Generate SLOs for Grafana SLO.
+
+The output is a list of SLOs in the Grafana SLO API. Each SLO can be posted to the API to create or update it.
+Only SLOs with a Prometheus indicator and a rolling objective window are supported.
+
+Grafana SLO generates fast-burn and slow-burn alerts with its own thresholds.
+Up to two burn rate alerts in the spec are mapped onto them in descending order of their burn rate thresholds,
+and the labels and annotations of their alerters are attached to the alerts. Error budget alerts are not supported.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specOptions, err := specFlags.SpecOptions()
+			if err != nil {
+				return err
+			}
+			return generate.GrafanaSLO(cmd.OutOrStdout(), args[0], &generate.GrafanaSLOOptions{
+				Output:                   output,
+				DestinationDatasourceUID: destinationDatasourceUID,
+				Spec:                     *specOptions,
+			})
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "json", "output format of the generated SLOs. Either \"json\", \"yaml\", \"go-template-file=<filename>\", \"go-template-dir=<dirname>\"")
+	command.Flags().StringVar(&destinationDatasourceUID, "destination-datasource-uid", "", "UID of the Prometheus data source where Grafana writes recording rules")
+	specFlags.AddFlags(command)
+
+	return command
+}
@@ -1,125 +1,48 @@
 package rule
 
 import (
-	"fmt"
-	"io"
-	"os"
-
 	"github.com/ajalab/slom/cmd/common"
-	configspec "github.com/ajalab/slom/internal/config/spec/native/v1alpha"
-	"github.com/ajalab/slom/internal/print"
-	"github.com/ajalab/slom/internal/prometheus/rule"
-	"github.com/ajalab/slom/internal/spec"
+	"github.com/ajalab/slom/internal/generate"
 	"github.com/spf13/cobra"
 )
 
-func run(typ string, output string, args []string, stdout io.Writer) error {
-	var alertEnabled bool
-	switch typ {
-	case "all":
-		alertEnabled = true
-	case "record":
-		alertEnabled = false
-	default:
-		return fmt.Errorf("either \"all\" or \"record\" must be specified as type")
-	}
-
-	fileName := args[0]
-	file, err := os.Open(fileName)
-	if err != nil {
-		return fmt.Errorf("failed to open %s: %w", fileName, err)
-	}
-	defer file.Close()
-
-	config, err := configspec.ParseSpecConfig(file)
-	if err != nil {
-		return fmt.Errorf("failed to parse %s: %w", fileName, err)
-	}
-
-	spec, err := spec.ToSpec(config)
-	if err != nil {
-		return fmt.Errorf("failed to convert a config %s into spec: %w", fileName, err)
-	}
-
-	switch output {
-	case "json":
-		return runJSON(spec, alertEnabled, stdout)
-	case "prometheus":
-		return runPrometheus(spec, alertEnabled, stdout)
-	}
-	return fmt.Errorf("unsupported format: %s", output)
-}
-
-func runJSON(
-	spec *spec.Spec,
-	alertEnabled bool,
-	stdout io.Writer,
-) error {
-	g := rule.NewRuleGenerator()
-	err := g.GenerateRecordingRules(spec)
-	if err != nil {
-		return fmt.Errorf("failed to generate recording rule groups")
-	}
-
-	printer := print.NewJSONPrinter(stdout)
-	defer printer.Close()
-
-	if !alertEnabled {
-		return printer.Print(g.RuleGroups())
-	}
-
-	err = g.GenerateAlertingRules(spec)
-	if err != nil {
-		return fmt.Errorf("failed to generate alerting rule groups: %w", err)
-	}
-
-	return printer.Print(g.RuleGroups())
-}
-
-func runPrometheus(
-	spec *spec.Spec,
-	alertEnabled bool,
-	stdout io.Writer,
-) error {
-	g := rule.NewRuleGenerator()
-	err := g.GenerateRecordingRules(spec)
-	if err != nil {
-		return fmt.Errorf("failed to generate recording rule groups")
-	}
-
-	printer := print.NewYAMLPrinter(stdout)
-	defer printer.Close()
-
-	if !alertEnabled {
-		recordingRuleGroups := g.RuleGroups()
-		prometheusRecordingRuleGroups := recordingRuleGroups.Prometheus()
-		return printer.Print(&prometheusRecordingRuleGroups)
-	}
-
-	err = g.GenerateAlertingRules(spec)
-	if err != nil {
-		return fmt.Errorf("failed to generate alerting rule groups: %w", err)
-	}
-
-	ruleGroups := g.RuleGroups()
-	prometheusRuleGroups := ruleGroups.Prometheus()
-	return printer.Print(&prometheusRuleGroups)
-}
-
 func NewCommand(flags *common.CommonFlags) *cobra.Command {
 	var typ string
 	var output string
+	var recordBurnRate bool
+	var recordSuccessRatio bool
+	var aggregateWindows bool
+	var shareRules bool
+	var specFlags common.SpecFlags
 
 	command := &cobra.Command{
-		Use:   "prometheus-rule [-t types] [-o output] file",
+		Use:   "prometheus-rule [-t types] [-o output] [--record-burn-rate] [--record-success-ratio] [--aggregate-windows] [--share-rules] [--overlay file]... [--var NAME=VALUE]... file",
 		Short: "Generate SLI recording or alerting rules for Prometheus-compatible systems",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return run(typ, output, args, cmd.OutOrStdout())
+			specOptions, err := specFlags.SpecOptions()
+			if err != nil {
+				return err
+			}
+			return generate.PrometheusRule(cmd.OutOrStdout(), args[0], &generate.PrometheusRuleOptions{
+				Type:               typ,
+				Output:             output,
+				RecordBurnRate:     recordBurnRate,
+				RecordSuccessRatio: recordSuccessRatio,
+				AggregateWindows:   aggregateWindows,
+				ShareRules:         shareRules,
+				Spec:               *specOptions,
+			})
 		},
 	}
 	command.Flags().StringVarP(&typ, "type", "t", "all", "rule types to generate. Either \"record\" or \"all\"")
-	command.Flags().StringVarP(&output, "output", "o", "prometheus", "output format of generated rules. Either \"json\" or \"prometheus\"")
+	command.Flags().StringVarP(&output, "output", "o", "prometheus", "output format of generated rules. Either \"prometheus\", \"json\", \"yaml\", \"go-template-file=<filename>\", \"go-template-dir=<dirname>\"")
+
+	command.Flags().BoolVar(&recordBurnRate, "record-burn-rate", false, "record the error budget burn rate over each window and compare it with the thresholds in burn rate alerts")
+	command.Flags().BoolVar(&recordSuccessRatio, "record-success-ratio", false, "record the success ratio (1 - error ratio) over each window")
+	command.Flags().BoolVar(&aggregateWindows, "aggregate-windows", false, "derive the series over longer windows from those over the shortest rolling window instead of querying raw series")
+	command.Flags().BoolVar(&shareRules, "share-rules", false, "record the series computed from the same indicator once for all SLOs in the spec")
+	specFlags.AddFlags(command)
 
 	return command
 }
@@ -2,57 +2,13 @@ package series
 
 import (
 	"fmt"
-	"io"
-	"os"
 	"time"
 
 	"github.com/ajalab/slom/cmd/common"
-	configseries "github.com/ajalab/slom/internal/config/series"
-	"github.com/ajalab/slom/internal/prometheus/series"
+	"github.com/ajalab/slom/internal/generate"
 	"github.com/spf13/cobra"
 )
 
-func run(
-	output string,
-	ruleFiles []string,
-	start time.Time,
-	end time.Time,
-	interval time.Duration,
-	args []string,
-	stdout io.Writer,
-) error {
-	fileName := args[0]
-	file, err := os.Open(fileName)
-	if err != nil {
-		return fmt.Errorf("failed to open %s: %w", fileName, err)
-	}
-	defer file.Close()
-
-	seriesConfigParser := configseries.SeriesConfigParser{
-		Start:    start,
-		End:      end,
-		Interval: interval,
-	}
-	seriesConfig, err := seriesConfigParser.Parse(file)
-	if err != nil {
-		return fmt.Errorf("failed to parse %s: %w", fileName, err)
-	}
-
-	g, err := series.NewSeriesSetGenerator(seriesConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create a generator: %w", err)
-	}
-
-	switch output {
-	case "openmetrics":
-		return g.GenerateOpenMetrics(stdout)
-	case "unittest":
-		return g.GenerateUnitTest(ruleFiles, stdout)
-	}
-
-	return fmt.Errorf("unsupported output format: %s", output)
-}
-
 func NewCommand(flags *common.CommonFlags) *cobra.Command {
 	var output string
 	var ruleFiles []string
@@ -84,20 +40,18 @@ func NewCommand(flags *common.CommonFlags) *cobra.Command {
 					return fmt.Errorf("failed to parse interval: %w", err)
 				}
 			}
-			return run(
-				output,
-				ruleFiles,
-				startTime,
-				endTime,
-				intervalDuration,
-				args,
-				cmd.OutOrStdout(),
-			)
+			return generate.PrometheusSeries(cmd.OutOrStdout(), args[0], &generate.PrometheusSeriesOptions{
+				Output:    output,
+				RuleFiles: ruleFiles,
+				Start:     startTime,
+				End:       endTime,
+				Interval:  intervalDuration,
+			})
 		},
 	}
 	command.Flags().SortFlags = false
 
-	command.Flags().StringVarP(&output, "output", "o", "openmetrics", "format of the output data. Either \"openmetrics\" for OpenMetrics time series or \"unittest\" for Promtool rule unit tests")
+	command.Flags().StringVarP(&output, "output", "o", "openmetrics", "format of the output data. Either \"openmetrics\" for OpenMetrics time series, \"unittest\" for Promtool rule unit tests, or \"json\", \"yaml\", \"go-template-file=<filename>\", \"go-template-dir=<dirname>\" for the series data")
 	command.Flags().StringArrayVarP(&ruleFiles, "rule-files", "r", []string{}, "list of rule file names.")
 	command.Flags().StringVarP(&start, "start", "s", "", "start time of the generated series in RFC3339")
 	command.Flags().StringVarP(&end, "end", "e", "", "end time of the generated series in RFC3339")
@@ -10,11 +10,10 @@ import (
 
 	"github.com/ajalab/slom/cmd/common"
 	configseries "github.com/ajalab/slom/internal/config/series"
-	configspec "github.com/ajalab/slom/internal/config/spec/native/v1alpha"
+	"github.com/ajalab/slom/internal/generate"
 	"github.com/ajalab/slom/internal/prometheus/rule"
 	"github.com/ajalab/slom/internal/prometheus/series"
 	"github.com/ajalab/slom/internal/prometheus/tsdb"
-	"github.com/ajalab/slom/internal/spec"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -114,22 +113,12 @@ func writePrometheusRule(
 	w io.Writer,
 	specFileName string,
 ) error {
-	specFile, err := os.Open(specFileName)
+	spec, err := generate.LoadSpec(specFileName)
 	if err != nil {
-		return fmt.Errorf("failed to open %s: %w", specFileName, err)
+		return err
 	}
-	defer specFile.Close()
 
-	specConfig, err := configspec.ParseSpecConfig(specFile)
-	if err != nil {
-		return fmt.Errorf("failed to parse %s: %w", specFileName, err)
-	}
-	spec, err := spec.ToSpec(specConfig)
-	if err != nil {
-		return fmt.Errorf("failed to convert a config %s into spec: %w", specFileName, err)
-	}
-
-	g := rule.NewRuleGenerator()
+	g := rule.NewRuleGenerator(nil)
 	err = g.GenerateRecordingRules(spec)
 	if err != nil {
 		return fmt.Errorf("failed to generate recording rule groups")
@@ -0,0 +1,28 @@
+package migrate
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var to string
+	var write bool
+
+	command := &cobra.Command{
+		Use:   "migrate [--to version] [-w] specFileName",
+		Short: "Migrate a spec file to a newer schema version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generate.Migrate(cmd.OutOrStdout(), args[0], &generate.MigrateOptions{
+				To:    to,
+				Write: write,
+			})
+		},
+	}
+	command.Flags().StringVar(&to, "to", "", "schema version to migrate the spec file to. Defaults to the latest version")
+	command.Flags().BoolVarP(&write, "write", "w", false, "rewrite the spec file instead of printing the result")
+
+	return command
+}
@@ -0,0 +1,27 @@
+package render
+
+import (
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var specFlags common.SpecFlags
+
+	command := &cobra.Command{
+		Use:   "render [--overlay file]... [--var NAME=VALUE]... specFileName",
+		Short: "Render a spec file with overlays applied and variables substituted",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options, err := specFlags.SpecOptions()
+			if err != nil {
+				return err
+			}
+			return generate.Render(cmd.OutOrStdout(), args[0], options)
+		},
+	}
+	specFlags.AddFlags(command)
+
+	return command
+}
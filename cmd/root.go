@@ -3,8 +3,12 @@ package cmd
 import (
 	"io"
 
+	"github.com/ajalab/slom/cmd/build"
 	"github.com/ajalab/slom/cmd/common"
 	"github.com/ajalab/slom/cmd/generate"
+	"github.com/ajalab/slom/cmd/migrate"
+	"github.com/ajalab/slom/cmd/render"
+	"github.com/ajalab/slom/cmd/serve"
 	"github.com/ajalab/slom/cmd/version"
 	"github.com/spf13/cobra"
 )
@@ -16,7 +20,11 @@ func Execute(args []string, stdout, stderr io.Writer) error {
 	}
 	rootCmd.SilenceUsage = true
 	rootCmd.PersistentFlags().BoolVarP(&commonFlags.Debug, "debug", "d", false, "enable debug logging")
+	rootCmd.AddCommand(build.NewCommand(&commonFlags))
 	rootCmd.AddCommand(generate.NewCommand(&commonFlags))
+	rootCmd.AddCommand(migrate.NewCommand(&commonFlags))
+	rootCmd.AddCommand(render.NewCommand(&commonFlags))
+	rootCmd.AddCommand(serve.NewCommand(&commonFlags))
 	rootCmd.AddCommand(version.NewCommand())
 
 	rootCmd.SetOut(stdout)
@@ -0,0 +1,72 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ajalab/slom/cmd/common"
+	"github.com/ajalab/slom/internal/serve"
+	"github.com/spf13/cobra"
+)
+
+func run(ctx context.Context, logger *slog.Logger, address string, dir string) error {
+	handler, err := serve.NewHandler(dir)
+	if err != nil {
+		return fmt.Errorf("failed to create a handler: %w", err)
+	}
+	server := &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	logger.Info("started serving specs", "address", address, "dir", dir)
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down the server: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	logger.Info("stopped serving specs")
+
+	return nil
+}
+
+func NewCommand(flags *common.CommonFlags) *cobra.Command {
+	var address string
+
+	command := &cobra.Command{
+		Use:   "serve [-a address] specDirName",
+		Short: "Serve SLO documents, Prometheus rules and specs over HTTP",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			logger := common.NewLogger(flags.Debug, cmd.ErrOrStderr())
+
+			return run(ctx, logger, address, args[0])
+		},
+	}
+	command.Flags().StringVarP(&address, "address", "a", "localhost:8080", "address to listen on")
+
+	return command
+}
@@ -0,0 +1,168 @@
+package alertmanager
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ajalab/slom/internal/prometheus/rule"
+	"github.com/ajalab/slom/internal/spec"
+)
+
+// DefaultSeverityLabel is the default label name that tells the severity of alerts.
+const DefaultSeverityLabel = "severity"
+
+// ConfigGeneratorOptions is a set of options for ConfigGenerator.
+type ConfigGeneratorOptions struct {
+	// DefaultReceiver is the receiver of the route subtree for the alerts of a spec.
+	DefaultReceiver string
+	// SeverityLabel is the label name that tells the severity of alerts.
+	SeverityLabel string
+	// Receivers maps values of the severity label to receivers.
+	Receivers map[string]string
+}
+
+// ConfigGenerator generates Alertmanager configuration fragments from specs.
+type ConfigGenerator struct {
+	options *ConfigGeneratorOptions
+}
+
+// NewConfigGenerator creates a ConfigGenerator.
+func NewConfigGenerator(options *ConfigGeneratorOptions) *ConfigGenerator {
+	if options == nil {
+		options = &ConfigGeneratorOptions{}
+	}
+	if options.SeverityLabel == "" {
+		options.SeverityLabel = DefaultSeverityLabel
+	}
+	return &ConfigGenerator{options: options}
+}
+
+// alert is an alert of an SLO with the labels identifying the alerting rule generated for it.
+type alert struct {
+	name      string
+	labels    map[string]string
+	threshold float64
+}
+
+// Generate generates a configuration fragment that routes and inhibits the alerts generated from s.
+func (g *ConfigGenerator) Generate(s *spec.Spec) (*Config, error) {
+	naming, err := rule.NewNaming(s)
+	if err != nil {
+		return nil, err
+	}
+
+	severities := map[string]struct{}{}
+	var inhibitRules []*InhibitRule
+	for _, slo := range s.SLOs() {
+		alerts, err := g.burnRateAlerts(slo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect alerts of SLO %s: %w", slo.Name(), err)
+		}
+		for _, a := range slo.Alerts() {
+			if alerter, ok := a.Alerter().(*spec.PrometheusAlerter); ok {
+				if severity, ok := alerter.Labels()[g.options.SeverityLabel]; ok {
+					severities[severity] = struct{}{}
+				}
+			}
+		}
+
+		var levels []string
+		if indicator, ok := slo.Indicator().(*spec.PrometheusIndicator); ok {
+			levels = indicator.Level()
+		}
+		equal := append([]string{naming.LabelNameId()}, levels...)
+
+		for i, source := range alerts {
+			for _, target := range alerts[i+1:] {
+				if source.threshold == target.threshold {
+					continue
+				}
+				r := &InhibitRule{
+					SourceMatchers: matchers(naming, s.Name(), source),
+					TargetMatchers: matchers(naming, s.Name(), target),
+					Equal:          equal,
+				}
+				if slices.Equal(r.SourceMatchers, r.TargetMatchers) {
+					// Alerts that cannot be told apart by their labels cannot inhibit each other.
+					continue
+				}
+				if !slices.ContainsFunc(inhibitRules, func(other *InhibitRule) bool { return inhibitRuleEqual(r, other) }) {
+					inhibitRules = append(inhibitRules, r)
+				}
+			}
+		}
+	}
+
+	route := &Route{
+		Receiver: g.options.DefaultReceiver,
+		GroupBy:  []string{"alertname", naming.LabelNameId()},
+		Matchers: []string{matcher(naming.LabelNameSpec(), s.Name())},
+	}
+	for _, severity := range slices.Sorted(maps.Keys(severities)) {
+		receiver, ok := g.options.Receivers[severity]
+		if !ok {
+			continue
+		}
+		route.Routes = append(route.Routes, &Route{
+			Receiver: receiver,
+			Matchers: []string{matcher(g.options.SeverityLabel, severity)},
+		})
+	}
+
+	return &Config{
+		Route:        route,
+		InhibitRules: inhibitRules,
+	}, nil
+}
+
+// burnRateAlerts returns the burn rate alerts of slo in descending order of their burn rate thresholds.
+func (g *ConfigGenerator) burnRateAlerts(slo *spec.SLO) ([]*alert, error) {
+	var alerts []*alert
+	for _, a := range slo.Alerts() {
+		a, ok := a.(*spec.BurnRateAlert)
+		if !ok {
+			continue
+		}
+		alerter, ok := a.Alerter().(*spec.PrometheusAlerter)
+		if !ok {
+			return nil, fmt.Errorf("only prometheus alerter is supported")
+		}
+		sloWindow := slo.Objective().Window()
+		if sloWindow == nil {
+			return nil, fmt.Errorf("SLO window is not defined")
+		}
+		alerts = append(alerts, &alert{
+			name:      alerter.Name(),
+			labels:    alerter.Labels(),
+			threshold: a.BurnRateThreshold(sloWindow),
+		})
+	}
+
+	slices.SortStableFunc(alerts, func(a, b *alert) int {
+		return cmp.Compare(b.threshold, a.threshold)
+	})
+	return alerts, nil
+}
+
+func matchers(naming *rule.Naming, specName string, a *alert) []string {
+	ms := []string{
+		matcher("alertname", a.name),
+		matcher(naming.LabelNameSpec(), specName),
+	}
+	for _, k := range slices.Sorted(maps.Keys(a.labels)) {
+		ms = append(ms, matcher(k, a.labels[k]))
+	}
+	return ms
+}
+
+func matcher(name string, value string) string {
+	return fmt.Sprintf("%s=%q", name, value)
+}
+
+func inhibitRuleEqual(a, b *InhibitRule) bool {
+	return slices.Equal(a.SourceMatchers, b.SourceMatchers) &&
+		slices.Equal(a.TargetMatchers, b.TargetMatchers) &&
+		slices.Equal(a.Equal, b.Equal)
+}
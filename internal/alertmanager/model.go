@@ -0,0 +1,23 @@
+package alertmanager
+
+// Config is a fragment of an Alertmanager configuration file.
+type Config struct {
+	Route        *Route         `json:"route,omitempty" yaml:"route,omitempty"`
+	InhibitRules []*InhibitRule `json:"inhibit_rules,omitempty" yaml:"inhibit_rules,omitempty"`
+}
+
+// Route is a node of the routing tree.
+type Route struct {
+	Receiver string   `json:"receiver,omitempty" yaml:"receiver,omitempty"`
+	GroupBy  []string `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	Matchers []string `json:"matchers,omitempty" yaml:"matchers,omitempty"`
+	Continue bool     `json:"continue,omitempty" yaml:"continue,omitempty"`
+	Routes   []*Route `json:"routes,omitempty" yaml:"routes,omitempty"`
+}
+
+// InhibitRule mutes alerts matching TargetMatchers while an alert matching SourceMatchers is firing.
+type InhibitRule struct {
+	SourceMatchers []string `json:"source_matchers" yaml:"source_matchers"`
+	TargetMatchers []string `json:"target_matchers" yaml:"target_matchers"`
+	Equal          []string `json:"equal" yaml:"equal"`
+}
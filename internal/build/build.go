@@ -0,0 +1,576 @@
+package build
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ajalab/slom/internal/config/project"
+	"github.com/ajalab/slom/internal/generate"
+)
+
+const defaultStateFileName = ".slom/state.json"
+
+const (
+	goTemplateFilePrefix = "go-template-file="
+	goTemplateDirPrefix  = "go-template-dir="
+)
+
+// Status is the result status of building an output.
+type Status string
+
+const (
+	StatusBuilt   Status = "built"
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+)
+
+// Result is the result of building an output of a target.
+type Result struct {
+	// Target is the name of the target.
+	Target string
+	// Input is the file name of the input.
+	Input string
+	// Output is the file name of the output.
+	Output string
+	// Status is the result status.
+	Status Status
+	// Err is the error occurred while building the output if Status is StatusFailed.
+	Err error
+}
+
+// Builder builds the targets in a project.
+type Builder struct {
+	dir    string
+	config *project.ProjectConfig
+	force  bool
+}
+
+// NewBuilder creates a builder for a project whose file names are relative to dir.
+func NewBuilder(dir string, config *project.ProjectConfig, force bool) *Builder {
+	return &Builder{
+		dir:    dir,
+		config: config,
+		force:  force,
+	}
+}
+
+// LoadProject reads a project file and creates a builder for it.
+func LoadProject(projectFileName string, force bool) (*Builder, error) {
+	projectFile, err := os.Open(projectFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", projectFileName, err)
+	}
+	defer projectFile.Close()
+
+	config, err := project.ParseProjectConfig(projectFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s as project config file: %w", projectFileName, err)
+	}
+
+	return NewBuilder(filepath.Dir(projectFileName), config, force), nil
+}
+
+// Build builds all the targets in the project.
+func (b *Builder) Build() ([]*Result, error) {
+	stateFileName := b.stateFileName()
+	state, err := loadState(stateFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the build state %s: %w", stateFileName, err)
+	}
+
+	var results []*Result
+	var jobs []*job
+	for i, t := range b.config.Targets {
+		targetJobs, err := b.jobs(&t)
+		if err != nil {
+			results = append(results, &Result{
+				Target: t.Name,
+				Status: StatusFailed,
+				Err:    fmt.Errorf("invalid target (index %d): %w", i, err),
+			})
+			continue
+		}
+		jobs = append(jobs, targetJobs...)
+	}
+
+	sloIdErrs := b.validateSLOIds(jobs)
+	outputs := map[string]string{}
+	for _, j := range jobs {
+		if target, ok := outputs[j.output]; ok {
+			results = append(results, &Result{
+				Target: j.target,
+				Input:  j.input,
+				Output: j.output,
+				Status: StatusFailed,
+				Err:    fmt.Errorf("output conflicts with another output in target %s", target),
+			})
+			continue
+		}
+		outputs[j.output] = j.target
+		if err, ok := sloIdErrs[j]; ok {
+			delete(state.Outputs, b.rel(j.output))
+			results = append(results, &Result{
+				Target: j.target,
+				Input:  j.input,
+				Output: j.output,
+				Status: StatusFailed,
+				Err:    err,
+			})
+			continue
+		}
+		results = append(results, b.runJob(state, j))
+	}
+
+	if err := state.save(stateFileName); err != nil {
+		return results, fmt.Errorf("failed to save the build state %s: %w", stateFileName, err)
+	}
+	return results, nil
+}
+
+// Inputs returns the file names of all the inputs in the project.
+func (b *Builder) Inputs() []string {
+	var inputs []string
+	for _, t := range b.config.Targets {
+		jobs, err := b.jobs(&t)
+		if err != nil {
+			continue
+		}
+		for _, j := range jobs {
+			inputs = append(inputs, j.inputs...)
+		}
+	}
+	return inputs
+}
+
+// Outputs returns the file names of all the outputs in the project.
+func (b *Builder) Outputs() []string {
+	var outputs []string
+	for _, t := range b.config.Targets {
+		jobs, err := b.jobs(&t)
+		if err != nil {
+			continue
+		}
+		for _, j := range jobs {
+			outputs = append(outputs, j.output)
+		}
+	}
+	return outputs
+}
+
+// validateSLOIds checks that the SLOs in different spec files don't have the same ID,
+// as the series recorded for them would be mixed up.
+// The same spec file may appear in multiple targets, and the first spec file with an ID owns it.
+// Spec files that can't be loaded are skipped as the errors are reported by their jobs.
+func (b *Builder) validateSLOIds(jobs []*job) map[*job]error {
+	errs := map[*job]error{}
+	inputsById := map[string]string{}
+	for _, j := range jobs {
+		if j.spec == nil {
+			continue
+		}
+		ids, err := generate.SLOIds(j.input, j.spec)
+		if err != nil {
+			continue
+		}
+		for _, id := range ids {
+			if input, ok := inputsById[id]; ok && input != j.input {
+				errs[j] = fmt.Errorf("SLO ID \"%s\" is also used in %s", id, b.rel(input))
+				break
+			}
+		}
+		if errs[j] != nil {
+			continue
+		}
+		for _, id := range ids {
+			inputsById[id] = j.input
+		}
+	}
+	return errs
+}
+
+func (b *Builder) stateFileName() string {
+	if b.config.StateFile != "" {
+		return b.path(b.config.StateFile)
+	}
+	return b.path(defaultStateFileName)
+}
+
+func (b *Builder) rel(name string) string {
+	if rel, err := filepath.Rel(b.dir, name); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return name
+}
+
+func (b *Builder) path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(b.dir, name)
+}
+
+func (b *Builder) paths(names []string) []string {
+	var paths []string
+	for _, name := range names {
+		paths = append(paths, b.path(name))
+	}
+	return paths
+}
+
+type job struct {
+	target string
+	input  string
+	output string
+	// inputs are the file names that affect the output.
+	inputs []string
+	// options are the options that affect the output.
+	// File names in options should be kept as written in the project file so that the digest doesn't depend on the working directory.
+	options any
+	// spec is the options to load the input if it is a spec file.
+	spec *generate.SpecOptions
+	run  func(w io.Writer) error
+}
+
+func (b *Builder) runJob(state *state, j *job) *Result {
+	result := &Result{
+		Target: j.target,
+		Input:  j.input,
+		Output: j.output,
+	}
+
+	key := b.rel(j.output)
+	digest, err := b.digest(j)
+	if err != nil {
+		delete(state.Outputs, key)
+		result.Status = StatusFailed
+		result.Err = fmt.Errorf("failed to compute the digest of inputs: %w", err)
+		return result
+	}
+
+	if !b.force && state.Outputs[key] == digest {
+		if _, err := os.Stat(j.output); err == nil {
+			result.Status = StatusSkipped
+			return result
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := j.run(&buf); err != nil {
+		delete(state.Outputs, key)
+		result.Status = StatusFailed
+		result.Err = err
+		return result
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.output), 0o755); err != nil {
+		delete(state.Outputs, key)
+		result.Status = StatusFailed
+		result.Err = fmt.Errorf("failed to create the output directory: %w", err)
+		return result
+	}
+	if err := os.WriteFile(j.output, buf.Bytes(), 0o644); err != nil {
+		delete(state.Outputs, key)
+		result.Status = StatusFailed
+		result.Err = fmt.Errorf("failed to write %s: %w", j.output, err)
+		return result
+	}
+
+	state.Outputs[key] = digest
+	result.Status = StatusBuilt
+	return result
+}
+
+func (b *Builder) digest(j *job) (string, error) {
+	h := sha256.New()
+
+	options, err := json.Marshal(j.options)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "options %d\n", len(options))
+	h.Write(options)
+
+	for _, input := range j.inputs {
+		content, err := os.ReadFile(input)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "input %s %d\n", b.rel(input), len(content))
+		h.Write(content)
+	}
+
+	// The rendered spec is included so that changes to the variables looked up from the environment variables trigger rebuilding.
+	if j.spec != nil {
+		// Errors in rendering are reported by the job.
+		var rendered bytes.Buffer
+		if err := generate.Render(&rendered, j.input, j.spec); err == nil {
+			fmt.Fprintf(h, "rendered %d\n", rendered.Len())
+			h.Write(rendered.Bytes())
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (b *Builder) jobs(t *project.TargetConfig) ([]*job, error) {
+	if t.Name == "" {
+		return nil, errors.New("name must be specified")
+	}
+	if t.OutDir == "" {
+		return nil, errors.New("outDir must be specified")
+	}
+
+	switch {
+	case t.PrometheusRule != nil && t.PrometheusSeries == nil && t.Document == nil:
+		return b.prometheusRuleJobs(t)
+	case t.PrometheusSeries != nil && t.PrometheusRule == nil && t.Document == nil:
+		return b.prometheusSeriesJobs(t)
+	case t.Document != nil && t.PrometheusRule == nil && t.PrometheusSeries == nil:
+		return b.documentJobs(t)
+	}
+	return nil, errors.New("either one of target types must be specified")
+}
+
+func (b *Builder) prometheusRuleJobs(t *project.TargetConfig) ([]*job, error) {
+	defaults := &b.config.Defaults.PrometheusRule
+	options := generate.PrometheusRuleOptions{
+		Type:               valueOrDefault(t.PrometheusRule.Type, defaults.Type, "all"),
+		Output:             valueOrDefault(t.PrometheusRule.Output, defaults.Output, "prometheus"),
+		RecordBurnRate:     boolOrDefault(t.PrometheusRule.RecordBurnRate, defaults.RecordBurnRate),
+		RecordSuccessRatio: boolOrDefault(t.PrometheusRule.RecordSuccessRatio, defaults.RecordSuccessRatio),
+		AggregateWindows:   boolOrDefault(t.PrometheusRule.AggregateWindows, defaults.AggregateWindows),
+		ShareRules:         boolOrDefault(t.PrometheusRule.ShareRules, defaults.ShareRules),
+		Spec:               b.specOptions(t),
+	}
+	digestOptions := options
+
+	var templateInputs []string
+	var err error
+	if options.Output, templateInputs, err = b.templateOutput(options.Output); err != nil {
+		return nil, err
+	}
+	options.Spec.Overlays = b.paths(t.Overlays)
+
+	inputs, err := b.inputs(t, b.config.Specs)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*job
+	for _, input := range inputs {
+		jobs = append(jobs, &job{
+			target:  t.Name,
+			input:   input,
+			output:  b.output(t, input, options.Output),
+			inputs:  append(specInputs(input, &options.Spec), templateInputs...),
+			options: digestOptions,
+			spec:    &options.Spec,
+			run: func(w io.Writer) error {
+				return generate.PrometheusRule(w, input, &options)
+			},
+		})
+	}
+	return jobs, nil
+}
+
+func (b *Builder) prometheusSeriesJobs(t *project.TargetConfig) ([]*job, error) {
+	defaults := &b.config.Defaults.PrometheusSeries
+	options := generate.PrometheusSeriesOptions{
+		Output:    valueOrDefault(t.PrometheusSeries.Output, defaults.Output, "openmetrics"),
+		RuleFiles: t.PrometheusSeries.RuleFiles,
+	}
+	if options.RuleFiles == nil {
+		options.RuleFiles = defaults.RuleFiles
+	}
+
+	var err error
+	if start := valueOrDefault(t.PrometheusSeries.Start, defaults.Start, ""); start != "" {
+		if options.Start, err = time.Parse(time.RFC3339, start); err != nil {
+			return nil, fmt.Errorf("failed to parse date time in start: %w", err)
+		}
+	}
+	if end := valueOrDefault(t.PrometheusSeries.End, defaults.End, ""); end != "" {
+		if options.End, err = time.Parse(time.RFC3339, end); err != nil {
+			return nil, fmt.Errorf("failed to parse date time in end: %w", err)
+		}
+	}
+	if interval := valueOrDefault(t.PrometheusSeries.Interval, defaults.Interval, ""); interval != "" {
+		if options.Interval, err = time.ParseDuration(interval); err != nil {
+			return nil, fmt.Errorf("failed to parse interval: %w", err)
+		}
+	}
+	digestOptions := options
+
+	var templateInputs []string
+	if options.Output, templateInputs, err = b.templateOutput(options.Output); err != nil {
+		return nil, err
+	}
+
+	inputs, err := b.inputs(t, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*job
+	for _, input := range inputs {
+		jobs = append(jobs, &job{
+			target:  t.Name,
+			input:   input,
+			output:  b.output(t, input, options.Output),
+			inputs:  append([]string{input}, templateInputs...),
+			options: digestOptions,
+			run: func(w io.Writer) error {
+				return generate.PrometheusSeries(w, input, &options)
+			},
+		})
+	}
+	return jobs, nil
+}
+
+func (b *Builder) documentJobs(t *project.TargetConfig) ([]*job, error) {
+	defaults := &b.config.Defaults.Document
+	options := generate.DocumentOptions{
+		Output: valueOrDefault(t.Document.Output, defaults.Output, "json"),
+		Spec:   b.specOptions(t),
+	}
+	digestOptions := options
+
+	var templateInputs []string
+	var err error
+	if options.Output, templateInputs, err = b.templateOutput(options.Output); err != nil {
+		return nil, err
+	}
+	options.Spec.Overlays = b.paths(t.Overlays)
+
+	inputs, err := b.inputs(t, b.config.Specs)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*job
+	for _, input := range inputs {
+		jobs = append(jobs, &job{
+			target:  t.Name,
+			input:   input,
+			output:  b.output(t, input, options.Output),
+			inputs:  append(specInputs(input, &options.Spec), templateInputs...),
+			options: digestOptions,
+			spec:    &options.Spec,
+			run: func(w io.Writer) error {
+				return generate.Document(w, input, &options)
+			},
+		})
+	}
+	return jobs, nil
+}
+
+// specOptions returns the options to load the input spec files of a target.
+// File names in the options are kept as written in the project file.
+func (b *Builder) specOptions(t *project.TargetConfig) generate.SpecOptions {
+	return generate.SpecOptions{
+		Overlays: t.Overlays,
+		Vars:     t.Vars,
+		Naming:   b.config.Naming,
+	}
+}
+
+// specInputs returns the files read to load a spec file so that changes to any of them trigger rebuilding.
+// If the spec file can't be loaded, the spec file and the overlays are returned and the error is reported by the job.
+func specInputs(input string, options *generate.SpecOptions) []string {
+	inputs, err := generate.SpecInputs(input, options)
+	if err != nil {
+		return append([]string{input}, options.Overlays...)
+	}
+	return inputs
+}
+
+// templateOutput resolves the template file names in a go-template output format relative to the project directory.
+// It also returns the template files so that changes to them trigger rebuilding.
+func (b *Builder) templateOutput(output string) (string, []string, error) {
+	if goTemplateFileName, ok := strings.CutPrefix(output, goTemplateFilePrefix); ok {
+		goTemplateFileName = b.path(goTemplateFileName)
+		return goTemplateFilePrefix + goTemplateFileName, []string{goTemplateFileName}, nil
+	}
+	if goTemplateDirName, ok := strings.CutPrefix(output, goTemplateDirPrefix); ok {
+		goTemplateDirName = b.path(goTemplateDirName)
+		goTemplateFileNames, err := filepath.Glob(filepath.Join(goTemplateDirName, "*.tmpl"))
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to look up templates in %s: %w", goTemplateDirName, err)
+		}
+		return goTemplateDirPrefix + goTemplateDirName, goTemplateFileNames, nil
+	}
+	return output, nil, nil
+}
+
+func (b *Builder) inputs(t *project.TargetConfig, defaultPatterns []string) ([]string, error) {
+	patterns := t.Inputs
+	if len(patterns) == 0 {
+		patterns = defaultPatterns
+	}
+	if len(patterns) == 0 {
+		return nil, errors.New("inputs must be specified")
+	}
+
+	var inputs []string
+	for _, pattern := range patterns {
+		pattern = b.path(pattern)
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up input files %s: %w", pattern, err)
+		}
+		inputs = append(inputs, matches...)
+	}
+	return inputs, nil
+}
+
+func (b *Builder) output(t *project.TargetConfig, input string, output string) string {
+	extension := t.Extension
+	if extension == "" {
+		extension = defaultExtension(output)
+	}
+
+	base := filepath.Base(input)
+	name := base[:len(base)-len(filepath.Ext(base))]
+	return filepath.Join(b.path(t.OutDir), name+extension)
+}
+
+func defaultExtension(output string) string {
+	switch output {
+	case "json":
+		return ".json"
+	case "yaml", "prometheus", "unittest":
+		return ".yaml"
+	case "openmetrics":
+		return ".openmetrics"
+	}
+	return ".txt"
+}
+
+func boolOrDefault(value *bool, defaultValue *bool) bool {
+	if value != nil {
+		return *value
+	}
+	if defaultValue != nil {
+		return *defaultValue
+	}
+	return false
+}
+
+func valueOrDefault(value string, defaultValue string, fallback string) string {
+	if value != "" {
+		return value
+	}
+	if defaultValue != "" {
+		return defaultValue
+	}
+	return fallback
+}
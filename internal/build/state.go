@@ -0,0 +1,45 @@
+package build
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// state is the record of the last build.
+type state struct {
+	// Outputs maps output file names to the digests of the inputs they were built from.
+	Outputs map[string]string `json:"outputs"`
+}
+
+func loadState(fileName string) (*state, error) {
+	s := &state{Outputs: map[string]string{}}
+
+	content, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, s); err != nil {
+		return nil, err
+	}
+	if s.Outputs == nil {
+		s.Outputs = map[string]string{}
+	}
+	return s, nil
+}
+
+func (s *state) save(fileName string) error {
+	content, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, content, 0o644)
+}
@@ -0,0 +1,90 @@
+package project
+
+import (
+	core "github.com/ajalab/slom/internal/config/spec/core/v1alpha"
+)
+
+// ProjectConfig is a configuration for a slom project.
+type ProjectConfig struct {
+	// Version is the version of the configuration schema.
+	Version string `yaml:"version"`
+	// Specs are glob patterns of spec files used by targets that don't specify their own inputs.
+	Specs []string `yaml:"specs,omitempty"`
+	// StateFile is the file name where the input digests of the last build are recorded.
+	StateFile string `yaml:"stateFile,omitempty"`
+	// Naming is the naming scheme of the SLO IDs and the recorded series used for spec files that don't specify their own.
+	Naming *core.NamingConfig `yaml:"naming,omitempty"`
+	// Defaults are the default options shared among targets.
+	Defaults TargetDefaultsConfig `yaml:"defaults,omitempty"`
+	// Targets are output target configurations.
+	Targets []TargetConfig `yaml:"targets"`
+}
+
+// TargetDefaultsConfig is a configuration for the default options of targets.
+type TargetDefaultsConfig struct {
+	// PrometheusRule is the default options of prometheus-rule targets.
+	PrometheusRule PrometheusRuleTargetConfig `yaml:"prometheusRule,omitempty"`
+	// PrometheusSeries is the default options of prometheus-series targets.
+	PrometheusSeries PrometheusSeriesTargetConfig `yaml:"prometheusSeries,omitempty"`
+	// Document is the default options of document targets.
+	Document DocumentTargetConfig `yaml:"document,omitempty"`
+}
+
+// TargetConfig is a configuration for an output target.
+type TargetConfig struct {
+	// Name is the name of the target.
+	Name string `yaml:"name"`
+	// Inputs are glob patterns of input files. Defaults to ProjectConfig.Specs.
+	Inputs []string `yaml:"inputs,omitempty"`
+	// OutDir is the directory where output files are written.
+	OutDir string `yaml:"outDir"`
+	// Extension is the file extension of output files (e.g., ".yaml").
+	Extension string `yaml:"extension,omitempty"`
+	// Overlays are spec files applied to each input spec file in order.
+	Overlays []string `yaml:"overlays,omitempty"`
+	// Vars are the values of the variables substituted in the input spec files before the environment variables.
+	Vars map[string]string `yaml:"vars,omitempty"`
+
+	// PrometheusRule specifies the target as Prometheus rules generated from spec files.
+	PrometheusRule *PrometheusRuleTargetConfig `yaml:"prometheusRule,omitempty"`
+	// PrometheusSeries specifies the target as Prometheus time series generated from series files.
+	PrometheusSeries *PrometheusSeriesTargetConfig `yaml:"prometheusSeries,omitempty"`
+	// Document specifies the target as SLO documents generated from spec files.
+	Document *DocumentTargetConfig `yaml:"document,omitempty"`
+}
+
+// PrometheusRuleTargetConfig is a configuration for a target of Prometheus rules.
+type PrometheusRuleTargetConfig struct {
+	// Type is the rule types to generate. Either "all" or "record".
+	Type string `yaml:"type,omitempty"`
+	// Output is the output format of the rules.
+	Output string `yaml:"output,omitempty"`
+	// RecordBurnRate enables recording rules for the error budget burn rate over each window.
+	RecordBurnRate *bool `yaml:"recordBurnRate,omitempty"`
+	// RecordSuccessRatio enables recording rules for the success ratio over each window.
+	RecordSuccessRatio *bool `yaml:"recordSuccessRatio,omitempty"`
+	// AggregateWindows derives the series over longer windows from those over the shortest rolling window.
+	AggregateWindows *bool `yaml:"aggregateWindows,omitempty"`
+	// ShareRules records the series computed from the same indicator once for all SLOs in a spec.
+	ShareRules *bool `yaml:"shareRules,omitempty"`
+}
+
+// PrometheusSeriesTargetConfig is a configuration for a target of Prometheus time series.
+type PrometheusSeriesTargetConfig struct {
+	// Output is the output format of the series.
+	Output string `yaml:"output,omitempty"`
+	// RuleFiles are the rule file names referred from rule unit tests.
+	RuleFiles []string `yaml:"ruleFiles,omitempty"`
+	// Start overrides the start time of series in RFC3339.
+	Start string `yaml:"start,omitempty"`
+	// End overrides the end time of series in RFC3339.
+	End string `yaml:"end,omitempty"`
+	// Interval overrides the interval of series.
+	Interval string `yaml:"interval,omitempty"`
+}
+
+// DocumentTargetConfig is a configuration for a target of SLO documents.
+type DocumentTargetConfig struct {
+	// Output is the output format of the documents.
+	Output string `yaml:"output,omitempty"`
+}
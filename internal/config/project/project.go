@@ -0,0 +1,19 @@
+package project
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+func ParseProjectConfig(r io.Reader) (*ProjectConfig, error) {
+	var config ProjectConfig
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
@@ -25,12 +25,26 @@ type ObjectiveConfig struct {
 	Ratio float64 `yaml:"ratio"`
 	// WindowRef is the window name that refers to a window defined in SLOConfig.Windows.
 	WindowRef string `yaml:"windowRef"`
+	// RatioByLabel varies the target ratio by the values of a label (optional).
+	RatioByLabel *RatioByLabelConfig `yaml:"ratioByLabel,omitempty"`
+}
+
+// RatioByLabelConfig is a configuration for target ratios that vary by the values of a label.
+type RatioByLabelConfig struct {
+	// Label is the name of the label. It must be one of the labels in the level of the Prometheus indicator.
+	Label string `yaml:"label"`
+	// Ratios are the target ratios keyed by the label values.
+	Ratios map[string]float64 `yaml:"ratios"`
 }
 
 // IndicatorConfig is a configuration for a service level indicator (SLI).
 type IndicatorConfig struct {
 	// Prometheus is an SLI implemented with Prometheus.
 	Prometheus *PrometheusIndicatorConfig `yaml:"prometheus,omitempty"`
+	// Datadog is an SLI implemented with Datadog metrics.
+	Datadog *DatadogIndicatorConfig `yaml:"datadog,omitempty"`
+	// GoogleCloudMonitoring is an SLI implemented with Google Cloud Monitoring time series.
+	GoogleCloudMonitoring *GoogleCloudMonitoringIndicatorConfig `yaml:"googleCloudMonitoring,omitempty"`
 }
 
 // PrometheusIndicatorConfig is a configuration for an SLI implemented with Prometheus.
@@ -41,6 +55,28 @@ type PrometheusIndicatorConfig struct {
 	//
 	// [aggregation level]: https://prometheus.io/docs/practices/rules/#naming
 	Level []string `yaml:"level,omitempty"`
+	// TotalEvents is a PromQL query that computes the number of events of a service over $window (optional).
+	TotalEvents string `yaml:"totalEvents,omitempty"`
+}
+
+// DatadogIndicatorConfig is a configuration for an SLI implemented with Datadog metrics.
+type DatadogIndicatorConfig struct {
+	// Good is a Datadog metric query that counts good events.
+	Good string `yaml:"good"`
+	// Total is a Datadog metric query that counts all events.
+	Total string `yaml:"total"`
+}
+
+// GoogleCloudMonitoringIndicatorConfig is a configuration for a request-based SLI implemented with Google Cloud Monitoring.
+type GoogleCloudMonitoringIndicatorConfig struct {
+	// Service is the resource name of the Cloud Monitoring service that the SLO belongs to.
+	Service string `yaml:"service"`
+	// GoodServiceFilter is a monitoring filter that selects time series counting good events.
+	GoodServiceFilter string `yaml:"goodServiceFilter,omitempty"`
+	// BadServiceFilter is a monitoring filter that selects time series counting bad events.
+	BadServiceFilter string `yaml:"badServiceFilter,omitempty"`
+	// TotalServiceFilter is a monitoring filter that selects time series counting all events.
+	TotalServiceFilter string `yaml:"totalServiceFilter,omitempty"`
 }
 
 // WindowConfig is a configuration for a window used by SLIs and SLOs.
@@ -76,7 +112,7 @@ type PrometheusWindowConfig struct {
 }
 
 // AlertConfig is a configuration for SLO alerts.
-// Either the BurnRate or ErrorBudget field must be specified.
+// Either the BurnRate, ErrorBudget, ErrorBudgetForecast or SLIAbsent field must be specified.
 type AlertConfig struct {
 	// Name is the name of the alert (optional).
 	Name string `yaml:"name"`
@@ -84,6 +120,10 @@ type AlertConfig struct {
 	BurnRate *BurnRateAlertConfig `yaml:"burnRate"`
 	// BurnRate specifies the alert as error budget consumption alert.
 	ErrorBudget *ErrorBudgetAlertConfig `yaml:"errorBudget"`
+	// ErrorBudgetForecast specifies the alert as error budget exhaustion forecast alert.
+	ErrorBudgetForecast *ErrorBudgetForecastAlertConfig `yaml:"errorBudgetForecast"`
+	// SLIAbsent specifies the alert as SLI absence alert.
+	SLIAbsent *SLIAbsentAlertConfig `yaml:"sliAbsent"`
 	// Alerter specifies how alerting is implemented for this alert.
 	Alerter AlerterConfig
 }
@@ -100,6 +140,9 @@ type BurnRateAlertConfig struct {
 	//
 	// [multiwindow]: https://sre.google/workbook/alerting-on-slos/#6-multiwindow-multi-burn-rate-alerts
 	MultiWindows *MultiWindowsBurnRateAlertConfig `yaml:"multiWindows"`
+
+	// MinEvents is the minimum number of events over the (long) alert window for the alert to fire (optional).
+	MinEvents int `yaml:"minEvents,omitempty"`
 }
 
 type SingleWindowBurnRateAlertConfig struct {
@@ -124,6 +167,23 @@ type MultiWindowsBurnRateAlertConfig struct {
 type ErrorBudgetAlertConfig struct {
 	// ConsumedBudgetRatio is the alerting threshold based on the ratio of the consumed error budget (0.0 - 1.0).
 	ConsumedBudgetRatio float64 `yaml:"consumedBudgetRatio"`
+
+	// MinEvents is the minimum number of events over the SLO window for the alert to fire (optional).
+	MinEvents int `yaml:"minEvents,omitempty"`
+}
+
+// ErrorBudgetForecastAlertConfig is a configuration for an SLO error budget exhaustion forecast alert.
+type ErrorBudgetForecastAlertConfig struct {
+	// Lookback is the period over which the trend of the error budget is computed in [time.Duration] format.
+	Lookback string `yaml:"lookback"`
+	// Horizon is how far ahead the error budget is forecast in [time.Duration] format.
+	Horizon string `yaml:"horizon,omitempty"`
+}
+
+// SLIAbsentAlertConfig is a configuration for an alert that fires when the SLI cannot be computed.
+type SLIAbsentAlertConfig struct {
+	// For is how long the SLI must be absent before the alert fires in [time.Duration] format (optional).
+	For string `yaml:"for,omitempty"`
 }
 
 // AlerterConfig is a configuration for alert implementation.
@@ -140,3 +200,65 @@ type PrometheusAlerterConfig struct {
 	// Annotations are the annotations attached to Prometheus alerts.
 	Annotations map[string]string `yaml:"annotations,omitempty"`
 }
+
+// NamingConfig is a configuration for the names of the series and labels recorded in Prometheus.
+type NamingConfig struct {
+	// SLOId is the format of the value that identifies an SLO with ${spec} and ${slo}. Defaults to "${spec}-${slo}".
+	SLOId string `yaml:"sloId,omitempty"`
+	// RuleGroup is the name format of rule groups with ${id} and ${kind}. Defaults to "slom:${id}:${kind}".
+	RuleGroup string `yaml:"ruleGroup,omitempty"`
+	// Labels are the names of the labels attached to the recorded series.
+	Labels NamingLabelsConfig `yaml:"labels,omitempty"`
+	// Metrics are the name templates of the recorded series with ${level} and ${window}.
+	Metrics NamingMetricsConfig `yaml:"metrics,omitempty"`
+}
+
+// NamingLabelsConfig is a configuration for the names of the labels attached to the recorded series.
+type NamingLabelsConfig struct {
+	// Spec is the name of the label for the spec name. Defaults to "slom_spec".
+	Spec string `yaml:"spec,omitempty"`
+	// SLO is the name of the label for the SLO name. Defaults to "slom_slo".
+	SLO string `yaml:"slo,omitempty"`
+	// Id is the name of the label for the SLO ID. Defaults to "slom_id".
+	Id string `yaml:"id,omitempty"`
+	// Indicator is the name of the label for the indicator of series shared by SLOs. Defaults to "slom_indicator".
+	Indicator string `yaml:"indicator,omitempty"`
+	// Alert is the name of the label for the alert name in metadata series. Defaults to "slom_alert".
+	Alert string `yaml:"alert,omitempty"`
+	// AlertIndex is the name of the label for the index of alerts in an SLO in metadata series. Defaults to "slom_alert_index".
+	AlertIndex string `yaml:"alertIndex,omitempty"`
+	// Window is the name of the label for the kind of alert windows in metadata series. Defaults to "slom_window".
+	Window string `yaml:"window,omitempty"`
+	// SpecVersion is the name of the label for the spec version in metadata series. Defaults to "slom_spec_version".
+	SpecVersion string `yaml:"specVersion,omitempty"`
+	// SpecHash is the name of the label for the spec hash in metadata series. Defaults to "slom_spec_hash".
+	SpecHash string `yaml:"specHash,omitempty"`
+}
+
+// NamingMetricsConfig is a configuration for the name templates of the recorded series.
+type NamingMetricsConfig struct {
+	// ErrorRate is the name template of the error rate. Defaults to "${level}slom_error:ratio_rate${window}".
+	ErrorRate string `yaml:"errorRate,omitempty"`
+	// BurnRate is the name template of the error budget burn rate. Defaults to "${level}slom_burn_rate:ratio_rate${window}".
+	BurnRate string `yaml:"burnRate,omitempty"`
+	// SuccessRate is the name template of the success rate. Defaults to "${level}slom_success:ratio_rate${window}".
+	SuccessRate string `yaml:"successRate,omitempty"`
+	// ErrorBudget is the name template of the remaining error budget. Defaults to "${level}slom_error_budget:ratio_rate${window}".
+	ErrorBudget string `yaml:"errorBudget,omitempty"`
+	// Events is the name template of the number of events. Defaults to "${level}slom_events:increase${window}".
+	Events string `yaml:"events,omitempty"`
+	// ErrorRatioNumerator is the name template of the numerator of the error ratio query. Defaults to "${level}slom_error_ratio:numerator${window}".
+	ErrorRatioNumerator string `yaml:"errorRatioNumerator,omitempty"`
+	// ErrorRatioDenominator is the name template of the denominator of the error ratio query. Defaults to "${level}slom_error_ratio:denominator${window}".
+	ErrorRatioDenominator string `yaml:"errorRatioDenominator,omitempty"`
+	// SLO is the name of the objective ratio of SLOs. Defaults to "slom_slo".
+	SLO string `yaml:"slo,omitempty"`
+	// SLOWindow is the name of the duration of SLO windows in seconds. Defaults to "slom_slo_window_seconds".
+	SLOWindow string `yaml:"sloWindow,omitempty"`
+	// AlertBurnRateThreshold is the name of the thresholds of burn rate alerts. Defaults to "slom_alert_burn_rate_threshold".
+	AlertBurnRateThreshold string `yaml:"alertBurnRateThreshold,omitempty"`
+	// AlertWindow is the name of the duration of the windows of burn rate alerts in seconds. Defaults to "slom_alert_window_seconds".
+	AlertWindow string `yaml:"alertWindow,omitempty"`
+	// SpecInfo is the name of the series that carries the version and the hash of specs. Defaults to "slom_spec_info".
+	SpecInfo string `yaml:"specInfo,omitempty"`
+}
@@ -0,0 +1,65 @@
+package native
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseSpecConfigFile parses a spec config file with the parser of its schema version, and returns the files read.
+func ParseSpecConfigFile(fileName string) (*yaml.Node, []string, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	node, err := ParseSpecConfigNode(f)
+	f.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", fileName, err)
+	}
+
+	s, err := nodeSchema(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.parseFile(fileName)
+}
+
+// ApplyOverlay merges overlay, a partial spec config, into base with the rules of the schema version of base.
+func ApplyOverlay(base *yaml.Node, overlay *yaml.Node) error {
+	s, err := nodeSchema(base)
+	if err != nil {
+		return err
+	}
+	version, err := specifiedVersion(overlay)
+	if err != nil {
+		return err
+	}
+	if version != "" && version != s.version {
+		return fmt.Errorf("overlay version %s doesn't match the spec config version %s", version, s.version)
+	}
+	return s.applyOverlay(base, overlay)
+}
+
+// SubstituteVariables replaces the variables in a spec config node with the values returned by lookup.
+func SubstituteVariables(node *yaml.Node, lookup func(string) (string, bool)) error {
+	s, err := nodeSchema(node)
+	if err != nil {
+		return err
+	}
+	return s.substituteVariables(node, lookup)
+}
+
+// nodeSchema returns the schema of the version of a spec config node.
+func nodeSchema(node *yaml.Node) (*schema, error) {
+	version, err := SpecConfigVersion(node)
+	if err != nil {
+		return nil, err
+	}
+	i, err := schemaIndex(version)
+	if err != nil {
+		return nil, err
+	}
+	return &schemas[i], nil
+}
@@ -15,6 +15,8 @@ type SpecConfig struct {
 	Labels map[string]string `yaml:"labels"`
 	// Annotations are the annotations of the SLO specification.
 	Annotations map[string]string `yaml:"annotations"`
+	// Naming is the naming scheme of the series and labels recorded in Prometheus (optional).
+	Naming *core.NamingConfig `yaml:"naming,omitempty"`
 	// SLOs are SLO configurations.
 	SLOs []SLOConfig `yaml:"slos,omitempty"`
 }
@@ -36,4 +38,7 @@ type SLOConfig struct {
 	Alerts []core.AlertConfig `yaml:"alerts,omitempty"`
 	// Windows are windows used by the SLI and SLO.
 	Windows []core.WindowConfig `yaml:"windows,omitempty"`
+
+	// Matrix expands the SLO configuration into an SLO for each combination of the values of "${matrix.<key>}" (optional).
+	Matrix map[string][]string `yaml:"matrix,omitempty"`
 }
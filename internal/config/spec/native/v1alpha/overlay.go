@@ -0,0 +1,155 @@
+package v1alpha
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// namedListKeys are the keys of lists whose elements are merged by their names when overlays are applied.
+var namedListKeys = map[string]struct{}{
+	"slos":    {},
+	"windows": {},
+	"alerts":  {},
+}
+
+// patchKey is the key of an element in a named list that removes the element with the same name when it is "delete".
+const patchKey = "$patch"
+
+var reVariable = regexp.MustCompile(`\$\{([A-Z_][A-Z0-9_]*)\}`)
+
+// ApplyOverlay merges overlay, a partial spec config, into base.
+func ApplyOverlay(base *yaml.Node, overlay *yaml.Node) error {
+	merged, err := mergeNode(documentContent(base), documentContent(overlay), "")
+	if err != nil {
+		return err
+	}
+	if base.Kind == yaml.DocumentNode {
+		base.Content = []*yaml.Node{merged}
+	} else {
+		*base = *merged
+	}
+	return nil
+}
+
+func documentContent(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+	return node
+}
+
+func mergeNode(base *yaml.Node, overlay *yaml.Node, key string) (*yaml.Node, error) {
+	switch {
+	case base.Kind == yaml.MappingNode && overlay.Kind == yaml.MappingNode:
+		return mergeMapping(base, overlay)
+	case base.Kind == yaml.SequenceNode && overlay.Kind == yaml.SequenceNode:
+		if _, ok := namedListKeys[key]; ok {
+			return mergeNamedList(base, overlay)
+		}
+	}
+	return overlay, nil
+}
+
+func mergeMapping(base *yaml.Node, overlay *yaml.Node) (*yaml.Node, error) {
+	for i := 0; i+1 < len(overlay.Content); i += 2 {
+		key, value := overlay.Content[i], overlay.Content[i+1]
+		j := mappingIndex(base, key.Value)
+		switch {
+		case value.Tag == "!!null" && j >= 0:
+			base.Content = append(base.Content[:j], base.Content[j+2:]...)
+		case value.Tag == "!!null":
+		case j >= 0:
+			merged, err := mergeNode(base.Content[j+1], value, key.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key.Value, err)
+			}
+			base.Content[j+1] = merged
+		default:
+			base.Content = append(base.Content, key, value)
+		}
+	}
+	return base, nil
+}
+
+func mergeNamedList(base *yaml.Node, overlay *yaml.Node) (*yaml.Node, error) {
+	for i, element := range overlay.Content {
+		name := elementName(element)
+		if name == "" {
+			return nil, fmt.Errorf("element %d in the overlay must have a name", i)
+		}
+
+		j := slices.IndexFunc(base.Content, func(n *yaml.Node) bool { return elementName(n) == name })
+		if k := mappingIndex(element, patchKey); k >= 0 {
+			if patch := element.Content[k+1].Value; patch != "delete" {
+				return nil, fmt.Errorf("unknown %s \"%s\" in element \"%s\"", patchKey, patch, name)
+			}
+			if j >= 0 {
+				base.Content = append(base.Content[:j], base.Content[j+1:]...)
+			}
+			continue
+		}
+
+		if j < 0 {
+			base.Content = append(base.Content, element)
+			continue
+		}
+		merged, err := mergeNode(base.Content[j], element, "")
+		if err != nil {
+			return nil, fmt.Errorf("\"%s\": %w", name, err)
+		}
+		base.Content[j] = merged
+	}
+	return base, nil
+}
+
+func elementName(node *yaml.Node) string {
+	if i := mappingIndex(node, "name"); i >= 0 {
+		return node.Content[i+1].Value
+	}
+	return ""
+}
+
+// mappingIndex returns the index of key in the content of a mapping node, or -1 if node doesn't have key.
+func mappingIndex(node *yaml.Node, key string) int {
+	if node.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// SubstituteVariables replaces "${VAR}" in the string values of node with the value of VAR returned by lookup.
+func SubstituteVariables(node *yaml.Node, lookup func(string) (string, bool)) error {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		var err error
+		value := reVariable.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+			name := reVariable.FindStringSubmatch(placeholder)[1]
+			v, ok := lookup(name)
+			if !ok && err == nil {
+				err = fmt.Errorf("variable %s is not defined", name)
+			}
+			return v
+		})
+		if err != nil {
+			return err
+		}
+		if value != node.Value && node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Tag = ""
+		}
+		node.Value = value
+		return nil
+	}
+	for _, n := range node.Content {
+		if err := SubstituteVariables(n, lookup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,11 +6,26 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-func ParseSpecConfig(r io.Reader) (*SpecConfig, error) {
-	var config SpecConfig
+// Version is the schema version of spec configs in this package.
+const Version = "v1alpha"
+
+// ParseSpecConfigNode parses a spec config as a YAML node so that it can be patched before it is decoded.
+func ParseSpecConfigNode(r io.Reader) (*yaml.Node, error) {
+	var node yaml.Node
 
 	decoder := yaml.NewDecoder(r)
-	if err := decoder.Decode(&config); err != nil {
+	if err := decoder.Decode(&node); err != nil {
+		return nil, err
+	}
+
+	return &node, nil
+}
+
+// DecodeSpecConfig decodes a spec config from a YAML node without checking its version.
+func DecodeSpecConfig(node *yaml.Node) (*SpecConfig, error) {
+	var config SpecConfig
+
+	if err := node.Decode(&config); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,205 @@
+package v1alpha
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// includeKey is the key of a mapping replaced with the content of the file.
+	includeKey = "$include"
+	// refKey is the key of a mapping replaced with the node that the reference points to.
+	refKey = "$ref"
+)
+
+// ParseSpecConfigFile parses a spec config file resolving its includes and references, and returns the files read.
+func ParseSpecConfigFile(fileName string) (*yaml.Node, []string, error) {
+	r := &resolver{files: make(map[string]*yaml.Node)}
+	node, err := r.resolveFile(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}, r.fileNames, nil
+}
+
+type resolver struct {
+	// files are the parsed files by their names.
+	files     map[string]*yaml.Node
+	fileNames []string
+	// stack is the includes and the references being resolved to detect cycles.
+	stack []string
+}
+
+func (r *resolver) load(fileName string) (*yaml.Node, error) {
+	if node, ok := r.files[fileName]; ok {
+		return node, nil
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	node, err := ParseSpecConfigNode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fileName, err)
+	}
+	content := documentContent(node)
+	r.files[fileName] = content
+	r.fileNames = append(r.fileNames, fileName)
+	return content, nil
+}
+
+func (r *resolver) resolveFile(fileName string) (*yaml.Node, error) {
+	return r.resolveTarget(fileName, "")
+}
+
+// resolveTarget resolves the node that pointer points to in fileName.
+func (r *resolver) resolveTarget(fileName string, pointer string) (*yaml.Node, error) {
+	key := fileName
+	if pointer != "" {
+		key += "#" + pointer
+	}
+	if slices.Contains(r.stack, key) {
+		return nil, fmt.Errorf("cycle detected: %s -> %s", strings.Join(r.stack, " -> "), key)
+	}
+	r.stack = append(r.stack, key)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	root, err := r.load(fileName)
+	if err != nil {
+		return nil, err
+	}
+	node, err := r.lookupPointer(root, pointer, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolve(copyNode(node), fileName)
+}
+
+// resolve replaces the includes and the references in node, which is contained in fileName.
+func (r *resolver) resolve(node *yaml.Node, fileName string) (*yaml.Node, error) {
+	if i := mappingIndex(node, includeKey); i >= 0 {
+		if len(node.Content) != 2 {
+			return nil, fmt.Errorf("%s:%d: %s must be the only key in the mapping", fileName, node.Line, includeKey)
+		}
+		include := node.Content[i+1].Value
+		resolved, err := r.resolveTarget(relativePath(fileName, include), "")
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: failed to include \"%s\": %w", fileName, node.Line, include, err)
+		}
+		return resolved, nil
+	}
+
+	if i := mappingIndex(node, refKey); i >= 0 {
+		ref := node.Content[i+1].Value
+		target, pointer, ok := strings.Cut(ref, "#")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: $ref \"%s\" must be in the form of <file>#<pointer>", fileName, node.Line, ref)
+		}
+		targetFileName := fileName
+		if target != "" {
+			targetFileName = relativePath(fileName, target)
+		}
+		resolved, err := r.resolveTarget(targetFileName, pointer)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: failed to resolve $ref \"%s\": %w", fileName, node.Line, ref, err)
+		}
+
+		overrides := &yaml.Node{Kind: yaml.MappingNode}
+		overrides.Content = append(overrides.Content, node.Content[:i]...)
+		overrides.Content = append(overrides.Content, node.Content[i+2:]...)
+		if len(overrides.Content) == 0 {
+			return resolved, nil
+		}
+		if resolved.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s:%d: $ref \"%s\" with other keys must point to a mapping", fileName, node.Line, ref)
+		}
+		overrides, err = r.resolve(overrides, fileName)
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j+1 < len(overrides.Content); j += 2 {
+			if k := mappingIndex(resolved, overrides.Content[j].Value); k >= 0 {
+				resolved.Content[k+1] = overrides.Content[j+1]
+			} else {
+				resolved.Content = append(resolved.Content, overrides.Content[j], overrides.Content[j+1])
+			}
+		}
+		return resolved, nil
+	}
+
+	for i, n := range node.Content {
+		resolved, err := r.resolve(n, fileName)
+		if err != nil {
+			return nil, err
+		}
+		node.Content[i] = resolved
+	}
+	return node, nil
+}
+
+// relativePath resolves fileName relative to the directory of source, the file that refers to it.
+func relativePath(source string, fileName string) string {
+	if filepath.IsAbs(fileName) {
+		return fileName
+	}
+	return filepath.Join(filepath.Dir(source), fileName)
+}
+
+// lookupPointer returns the node that a JSON pointer such as "/indicators/http" points to in root, the content of fileName.
+// The includes and the references on the path are resolved first so that the pointer can go through them.
+func (r *resolver) lookupPointer(root *yaml.Node, pointer string, fileName string) (*yaml.Node, error) {
+	if pointer == "" {
+		return root, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%s: pointer \"%s\" must start with /", fileName, pointer)
+	}
+
+	node := root
+	for _, token := range strings.Split(pointer[1:], "/") {
+		if mappingIndex(node, includeKey) >= 0 || mappingIndex(node, refKey) >= 0 {
+			resolved, err := r.resolve(copyNode(node), fileName)
+			if err != nil {
+				return nil, err
+			}
+			node = resolved
+		}
+
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node.Kind {
+		case yaml.MappingNode:
+			i := mappingIndex(node, token)
+			if i < 0 {
+				return nil, fmt.Errorf("%s: pointer \"%s\" not found: no key \"%s\"", fileName, pointer, token)
+			}
+			node = node.Content[i+1]
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil, fmt.Errorf("%s: pointer \"%s\" not found: no index \"%s\"", fileName, pointer, token)
+			}
+			node = node.Content[i]
+		default:
+			return nil, fmt.Errorf("%s: pointer \"%s\" not found: \"%s\" is not in a mapping or a sequence", fileName, pointer, token)
+		}
+	}
+	return node, nil
+}
+
+func copyNode(node *yaml.Node) *yaml.Node {
+	c := *node
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i, n := range node.Content {
+		c.Content[i] = copyNode(n)
+	}
+	return &c
+}
@@ -0,0 +1,178 @@
+// Package native dispatches spec configs in the native format to the packages of their schema versions.
+package native
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ajalab/slom/internal/config/spec/native/v1alpha"
+	"gopkg.in/yaml.v3"
+)
+
+const versionKey = "version"
+
+// schema is a schema version of spec configs.
+type schema struct {
+	version string
+	// parseFile parses a spec config file of the version and resolves the includes and the references in it.
+	// It also returns the names of the files read.
+	parseFile func(fileName string) (*yaml.Node, []string, error)
+	// applyOverlay merges an overlay into a spec config of the version.
+	applyOverlay func(base *yaml.Node, overlay *yaml.Node) error
+	// substituteVariables replaces the variables in a spec config of the version with the values returned by lookup.
+	substituteVariables func(node *yaml.Node, lookup func(string) (string, bool)) error
+	// decode decodes a spec config of the version into the spec config consumed by spec.ToSpec.
+	decode func(node *yaml.Node) (*v1alpha.SpecConfig, error)
+	// migrate rewrites a spec config of the previous version into the version.
+	// It is nil for the oldest version.
+	migrate func(node *yaml.Node) error
+}
+
+// schemas are the supported schema versions from the oldest to the latest.
+var schemas = []schema{
+	{
+		version:             v1alpha.Version,
+		parseFile:           v1alpha.ParseSpecConfigFile,
+		applyOverlay:        v1alpha.ApplyOverlay,
+		substituteVariables: v1alpha.SubstituteVariables,
+		decode:              v1alpha.DecodeSpecConfig,
+	},
+}
+
+// LatestVersion is the latest schema version of spec configs.
+var LatestVersion = schemas[len(schemas)-1].version
+
+// ParseSpecConfig parses a spec config and decodes it according to its schema version.
+func ParseSpecConfig(r io.Reader) (*v1alpha.SpecConfig, error) {
+	node, err := ParseSpecConfigNode(r)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeSpecConfig(node)
+}
+
+// ParseSpecConfigNode parses a spec config of any version as a YAML node without interpreting it.
+func ParseSpecConfigNode(r io.Reader) (*yaml.Node, error) {
+	var node yaml.Node
+
+	decoder := yaml.NewDecoder(r)
+	if err := decoder.Decode(&node); err != nil {
+		return nil, err
+	}
+
+	return &node, nil
+}
+
+// DecodeSpecConfig decodes a spec config node with the decoder of its schema version.
+func DecodeSpecConfig(node *yaml.Node) (*v1alpha.SpecConfig, error) {
+	version, err := SpecConfigVersion(node)
+	if err != nil {
+		return nil, err
+	}
+	i, err := schemaIndex(version)
+	if err != nil {
+		return nil, err
+	}
+	return schemas[i].decode(node)
+}
+
+// SpecConfigVersion returns the schema version of a spec config node, or the oldest one if unspecified.
+func SpecConfigVersion(node *yaml.Node) (string, error) {
+	version, err := specifiedVersion(node)
+	if err != nil {
+		return "", err
+	}
+	if version == "" {
+		return schemas[0].version, nil
+	}
+	return version, nil
+}
+
+// specifiedVersion returns the schema version specified in a spec config node, or an empty string if it doesn't specify one.
+func specifiedVersion(node *yaml.Node) (string, error) {
+	mapping := node
+	if mapping.Kind == yaml.DocumentNode && len(mapping.Content) > 0 {
+		mapping = mapping.Content[0]
+	}
+	if mapping.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("spec config must be a mapping")
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != versionKey {
+			continue
+		}
+		value := mapping.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return "", fmt.Errorf("%s must be a string", versionKey)
+		}
+		return value.Value, nil
+	}
+	return "", nil
+}
+
+// MigrateSpecConfig rewrites a spec config node from its schema version to version in place.
+func MigrateSpecConfig(node *yaml.Node, version string) error {
+	from, err := SpecConfigVersion(node)
+	if err != nil {
+		return err
+	}
+	i, err := schemaIndex(from)
+	if err != nil {
+		return err
+	}
+	j, err := schemaIndex(version)
+	if err != nil {
+		return err
+	}
+	if i > j {
+		return fmt.Errorf("cannot migrate spec config from %s to the older version %s", from, version)
+	}
+
+	for _, s := range schemas[i+1 : j+1] {
+		if err := s.migrate(node); err != nil {
+			return fmt.Errorf("failed to migrate spec config to %s: %w", s.version, err)
+		}
+	}
+	setVersion(node, version)
+	return nil
+}
+
+func schemaIndex(version string) (int, error) {
+	i := slices.IndexFunc(schemas, func(s schema) bool { return s.version == version })
+	if i < 0 {
+		var versions []string
+		for _, s := range schemas {
+			versions = append(versions, s.version)
+		}
+		return -1, fmt.Errorf("unsupported spec config version \"%s\". Supported versions are %s", version, strings.Join(versions, ", "))
+	}
+	return i, nil
+}
+
+// setVersion sets the version of a spec config node, adding the key at the top if it doesn't exist.
+func setVersion(node *yaml.Node, version string) {
+	mapping := node
+	if mapping.Kind == yaml.DocumentNode && len(mapping.Content) > 0 {
+		mapping = mapping.Content[0]
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == versionKey {
+			mapping.Content[i+1].Value = version
+			mapping.Content[i+1].Tag = "!!str"
+			return
+		}
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: versionKey}
+	if len(mapping.Content) > 0 {
+		// Keep the comment at the top of the file above the version.
+		key.HeadComment, mapping.Content[0].HeadComment = mapping.Content[0].HeadComment, ""
+	}
+	mapping.Content = append([]*yaml.Node{
+		key,
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: version},
+	}, mapping.Content...)
+}
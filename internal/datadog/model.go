@@ -0,0 +1,49 @@
+package datadog
+
+// SLOWithMonitors is a Datadog SLO with the monitors that alert on it.
+type SLOWithMonitors struct {
+	SLO      *SLO       `json:"slo"`
+	Monitors []*Monitor `json:"monitors"`
+}
+
+// SLO is a service level objective in the Datadog SLO API.
+type SLO struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Type        string          `json:"type"`
+	Query       SLOQuery        `json:"query"`
+	Thresholds  []*SLOThreshold `json:"thresholds"`
+	Tags        []string        `json:"tags"`
+}
+
+// SLOQuery is the query of a metric-based SLO.
+type SLOQuery struct {
+	Numerator   string `json:"numerator"`
+	Denominator string `json:"denominator"`
+}
+
+// SLOThreshold is the target of an SLO over a timeframe.
+type SLOThreshold struct {
+	Timeframe string  `json:"timeframe"`
+	Target    float64 `json:"target"`
+}
+
+// Monitor is a monitor in the Datadog Monitor API.
+type Monitor struct {
+	Name    string         `json:"name"`
+	Type    string         `json:"type"`
+	Query   string         `json:"query"`
+	Message string         `json:"message"`
+	Tags    []string       `json:"tags"`
+	Options MonitorOptions `json:"options"`
+}
+
+// MonitorOptions is the options of a monitor.
+type MonitorOptions struct {
+	Thresholds MonitorThresholds `json:"thresholds"`
+}
+
+// MonitorThresholds is the thresholds of a monitor.
+type MonitorThresholds struct {
+	Critical float64 `json:"critical"`
+}
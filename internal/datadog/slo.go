@@ -0,0 +1,184 @@
+package datadog
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/ajalab/slom/internal/prometheus/rule"
+	"github.com/ajalab/slom/internal/spec"
+)
+
+// SLOIdPlaceholder is the placeholder of the SLO ID to be replaced in the queries of SLO monitors.
+const SLOIdPlaceholder = "${slo_id}"
+
+// timeframes are the SLO windows supported by Datadog.
+var timeframes = map[time.Duration]string{
+	7 * 24 * time.Hour:  "7d",
+	30 * 24 * time.Hour: "30d",
+	90 * 24 * time.Hour: "90d",
+}
+
+// SLOGenerator generates Datadog SLOs and SLO monitors from specs.
+type SLOGenerator struct{}
+
+// NewSLOGenerator creates an SLOGenerator.
+func NewSLOGenerator() *SLOGenerator {
+	return &SLOGenerator{}
+}
+
+// Generate generates a metric-based SLO and its monitors for each SLO in s.
+func (g *SLOGenerator) Generate(s *spec.Spec) ([]*SLOWithMonitors, error) {
+	naming, err := rule.NewNaming(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var slos []*SLOWithMonitors
+	for _, slo := range s.SLOs() {
+		datadogSLO, err := g.generateSLO(naming, s, slo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate a Datadog SLO for SLO %s: %w", slo.Name(), err)
+		}
+		slos = append(slos, datadogSLO)
+	}
+	return slos, nil
+}
+
+func (g *SLOGenerator) generateSLO(naming *rule.Naming, s *spec.Spec, slo *spec.SLO) (*SLOWithMonitors, error) {
+	indicator, ok := slo.Indicator().(*spec.DatadogIndicator)
+	if !ok {
+		return nil, fmt.Errorf("only datadog indicator is supported")
+	}
+
+	if slo.Objective().RatioLabel() != "" {
+		return nil, fmt.Errorf("objectives by label values are not supported")
+	}
+
+	sloWindow, ok := slo.Objective().Window().(*spec.RollingWindow)
+	if !ok {
+		return nil, fmt.Errorf("the objective must have a rolling window")
+	}
+	timeframe, ok := timeframes[time.Duration(sloWindow.Duration())]
+	if !ok {
+		return nil, fmt.Errorf("window %s is not supported. Either 7d, 30d or 90d must be used", sloWindow.Duration())
+	}
+
+	labels := map[string]string{}
+	maps.Copy(labels, s.Labels())
+	maps.Copy(labels, slo.Labels())
+	labels[naming.LabelNameSpec()] = s.Name()
+	labels[naming.LabelNameSLO()] = slo.Name()
+	tags := toTags(labels)
+
+	var monitors []*Monitor
+	for _, a := range slo.Alerts() {
+		monitor, err := generateMonitor(s, slo, a, timeframe)
+		if err != nil {
+			return nil, err
+		}
+		monitor.Tags = append(slices.Clone(tags), monitor.Tags...)
+		monitors = append(monitors, monitor)
+	}
+	if monitors == nil {
+		monitors = []*Monitor{}
+	}
+
+	return &SLOWithMonitors{
+		SLO: &SLO{
+			Name:        s.Name() + " " + slo.Name(),
+			Description: slo.Annotations()["description"],
+			Type:        "metric",
+			Query: SLOQuery{
+				Numerator:   indicator.Good(),
+				Denominator: indicator.Total(),
+			},
+			Thresholds: []*SLOThreshold{{
+				Timeframe: timeframe,
+				Target:    math.Round(slo.Objective().Ratio()*100*1e6) / 1e6,
+			}},
+			Tags: tags,
+		},
+		Monitors: monitors,
+	}, nil
+}
+
+func generateMonitor(s *spec.Spec, slo *spec.SLO, a spec.Alert, timeframe string) (*Monitor, error) {
+	if a, ok := a.(spec.GuardedAlert); ok && a.MinEvents() > 0 {
+		return nil, fmt.Errorf("minEvents is not supported")
+	}
+
+	var name, query string
+	var threshold float64
+	switch a := a.(type) {
+	case *spec.BurnRateAlert:
+		threshold = a.BurnRateThreshold(slo.Objective().Window())
+		name = "burn rate"
+		switch w := a.Window().(type) {
+		case *spec.BurnRateAlertSingleWindow:
+			query = fmt.Sprintf(
+				"burn_rate(\"%s\").over(\"%s\").long_window(\"%s\") > %g",
+				SLOIdPlaceholder, timeframe, formatDuration(w.Window().Duration()), threshold,
+			)
+		case *spec.BurnRateAlertMultiWindows:
+			query = fmt.Sprintf(
+				"burn_rate(\"%s\").over(\"%s\").long_window(\"%s\").short_window(\"%s\") > %g",
+				SLOIdPlaceholder, timeframe, formatDuration(w.LongWindow().Duration()), formatDuration(w.ShortWindow().Duration()), threshold,
+			)
+		}
+	case *spec.ErrorBudgetAlert:
+		// error_budget() evaluates the percentage of the error budget consumed.
+		threshold = math.Round(a.ConsumedBudgetRatio()*100*1e6) / 1e6
+		name = "error budget"
+		query = fmt.Sprintf("error_budget(\"%s\").over(\"%s\") > %g", SLOIdPlaceholder, timeframe, threshold)
+	case *spec.ErrorBudgetForecastAlert:
+		return nil, fmt.Errorf("error budget forecast alerts are not supported")
+	case *spec.SLIAbsentAlert:
+		return nil, fmt.Errorf("SLI absence alerts are not supported")
+	default:
+		return nil, fmt.Errorf("unknown alert type: %T", a)
+	}
+
+	monitor := &Monitor{
+		Name:    fmt.Sprintf("%s %s %s", s.Name(), slo.Name(), name),
+		Type:    "slo alert",
+		Query:   query,
+		Tags:    []string{},
+		Options: MonitorOptions{Thresholds: MonitorThresholds{Critical: threshold}},
+	}
+	if a.Name() != "" {
+		monitor.Name = a.Name()
+	}
+	if alerter, ok := a.Alerter().(*spec.PrometheusAlerter); ok {
+		if a.Name() == "" && alerter.Name() != "" {
+			monitor.Name = alerter.Name()
+		}
+		monitor.Message = alerter.Annotations()["description"]
+		monitor.Tags = toTags(alerter.Labels())
+	}
+	return monitor, nil
+}
+
+func toTags(labels map[string]string) []string {
+	tags := []string{}
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		tags = append(tags, k+":"+labels[k])
+	}
+	return tags
+}
+
+// formatDuration formats d in the largest unit of days, hours and minutes that represents d exactly,
+// which is the format of windows accepted by Datadog.
+func formatDuration(d spec.Duration) string {
+	td := time.Duration(d)
+	switch {
+	case td%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", td/(24*time.Hour))
+	case td%time.Hour == 0:
+		return fmt.Sprintf("%dh", td/time.Hour)
+	default:
+		return fmt.Sprintf("%dm", td/time.Minute)
+	}
+}
@@ -0,0 +1,182 @@
+package document
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ajalab/slom/internal/spec"
+)
+
+// DefaultCatalogGroupBy is the spec labels used to group specs in a catalog by default.
+var DefaultCatalogGroupBy = []string{"team", "environment", "tier"}
+
+// Catalog is a document of multiple SLO specifications.
+type Catalog struct {
+	// GroupBy is the spec label names used to group specs.
+	GroupBy []string `yaml:"groupBy" json:"groupBy"`
+	// Groups are the groups of specs that share the same values of the GroupBy labels.
+	Groups []CatalogGroup `yaml:"groups" json:"groups"`
+}
+
+// CatalogGroup is a group of specs in a catalog.
+type CatalogGroup struct {
+	// Labels are the values of the GroupBy labels shared by the specs in the group.
+	Labels []CatalogLabel `yaml:"labels" json:"labels"`
+	// Specs are the specs in the group.
+	Specs []CatalogSpec `yaml:"specs" json:"specs"`
+}
+
+// CatalogLabel is a label that groups specs in a catalog.
+type CatalogLabel struct {
+	// Name is the name of the label.
+	Name string `yaml:"name" json:"name"`
+	// Value is the value of the label.
+	Value string `yaml:"value" json:"value"`
+}
+
+// CatalogSpec is a summary of an SLO specification in a catalog.
+type CatalogSpec struct {
+	// Name is the name of the SLO specification.
+	Name string `yaml:"name" json:"name"`
+	// Anchor is the identifier of the spec used to link to it within the catalog.
+	Anchor string `yaml:"anchor" json:"anchor"`
+	// Labels are the labels of the SLO specification.
+	Labels map[string]string `yaml:"labels" json:"labels"`
+	// Annotations are the annotations of the SLO specification.
+	Annotations map[string]string `yaml:"annotations" json:"annotations"`
+	// SLOs are the summaries of SLOs in the spec.
+	SLOs []CatalogSLO `yaml:"slos,omitempty" json:"slos,omitempty"`
+}
+
+// CatalogSLO is a summary of an SLO in a catalog.
+type CatalogSLO struct {
+	// Name is the name of the SLO.
+	Name string `yaml:"name" json:"name"`
+	// Anchor is the identifier of the SLO used to link to it within the catalog.
+	Anchor string `yaml:"anchor" json:"anchor"`
+	// Labels are the labels of the SLO.
+	Labels map[string]string `yaml:"labels" json:"labels"`
+	// Objective is the target of the SLO.
+	Objective Objective `yaml:"objective" json:"objective"`
+	// Indicator is the SLI for the SLO.
+	Indicator Indicator `yaml:"indicator" json:"indicator"`
+	// Windows are windows used by the SLI and SLO.
+	Windows []Window `yaml:"windows,omitempty" json:"windows,omitempty"`
+	// Alerts is the number of alerts defined for the SLO.
+	Alerts int `yaml:"alerts" json:"alerts"`
+}
+
+// ToCatalog creates a catalog of specs grouped by the values of the spec labels in groupBy.
+func ToCatalog(specs []*spec.Spec, groupBy []string) (*Catalog, error) {
+	names := make(map[string]bool)
+	groups := make(map[string]*CatalogGroup)
+	for _, s := range specs {
+		if names[s.Name()] {
+			return nil, fmt.Errorf("spec name %s is duplicated", s.Name())
+		}
+		names[s.Name()] = true
+
+		labels := make([]CatalogLabel, 0, len(groupBy))
+		values := make([]string, 0, len(groupBy))
+		for _, name := range groupBy {
+			value := s.Labels()[name]
+			labels = append(labels, CatalogLabel{Name: name, Value: value})
+			values = append(values, value)
+		}
+		key := strings.Join(values, "\x00")
+
+		group, ok := groups[key]
+		if !ok {
+			group = &CatalogGroup{Labels: labels}
+			groups[key] = group
+		}
+		group.Specs = append(group.Specs, toCatalogSpec(s))
+	}
+
+	catalog := &Catalog{
+		GroupBy: groupBy,
+		Groups:  make([]CatalogGroup, 0, len(groups)),
+	}
+	for _, group := range groups {
+		slices.SortFunc(group.Specs, func(a, b CatalogSpec) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+		catalog.Groups = append(catalog.Groups, *group)
+	}
+	slices.SortFunc(catalog.Groups, func(a, b CatalogGroup) int {
+		for i := range a.Labels {
+			if c := cmp.Compare(a.Labels[i].Value, b.Labels[i].Value); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	assignAnchors(catalog)
+
+	return catalog, nil
+}
+
+func toCatalogSpec(s *spec.Spec) CatalogSpec {
+	var slos []CatalogSLO
+	for _, slo := range s.SLOs() {
+		var windows []Window
+		for _, w := range slo.Windows() {
+			windows = append(windows, toWindow(w))
+		}
+
+		slos = append(slos, CatalogSLO{
+			Name:      slo.Name(),
+			Labels:    slo.Labels(),
+			Objective: toObjective(slo.Objective(), slo.Windows()),
+			Indicator: toIndicator(slo.Indicator()),
+			Windows:   windows,
+			Alerts:    len(slo.Alerts()),
+		})
+	}
+
+	return CatalogSpec{
+		Name:        s.Name(),
+		Labels:      s.Labels(),
+		Annotations: s.Annotations(),
+		SLOs:        slos,
+	}
+}
+
+// assignAnchors assigns a unique anchor to each spec and SLO in the catalog in the order they appear.
+// Names that map to the same anchor (e.g., "a b" and "a-b") are distinguished by numeric suffixes as GitHub does.
+func assignAnchors(catalog *Catalog) {
+	used := make(map[string]bool)
+	unique := func(anchor string) string {
+		candidate := anchor
+		for i := 1; used[candidate]; i++ {
+			candidate = fmt.Sprintf("%s-%d", anchor, i)
+		}
+		used[candidate] = true
+		return candidate
+	}
+
+	for i := range catalog.Groups {
+		for j := range catalog.Groups[i].Specs {
+			s := &catalog.Groups[i].Specs[j]
+			s.Anchor = unique("spec-" + toAnchor(s.Name))
+			for k := range s.SLOs {
+				s.SLOs[k].Anchor = unique(s.Anchor + "-slo-" + toAnchor(s.SLOs[k].Name))
+			}
+		}
+	}
+}
+
+// toAnchor converts name into a string usable as an HTML id and a URL fragment.
+func toAnchor(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', '0' <= r && r <= '9', r == '-', r == '_':
+			return r
+		case 'A' <= r && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return '-'
+	}, name)
+}
@@ -1,6 +1,9 @@
 package document
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/ajalab/slom/internal/spec"
 )
 
@@ -19,19 +22,58 @@ func ToDocument(spec *spec.Spec) *Document {
 }
 
 func toSLO(slo *spec.SLO) SLO {
+	var alerts []Alert
+	for _, a := range slo.Alerts() {
+		alerts = append(alerts, toAlert(slo.Objective(), a))
+	}
+
+	var windows []Window
+	for _, w := range slo.Windows() {
+		windows = append(windows, toWindow(w))
+	}
+
 	return SLO{
 		Name:        slo.Name(),
 		Labels:      slo.Labels(),
 		Annotations: slo.Annotations(),
-		Objective:   toObjective(slo.Objective()),
+		Objective:   toObjective(slo.Objective(), slo.Windows()),
 		Indicator:   toIndicator(slo.Indicator()),
+		Alerts:      alerts,
+		Windows:     windows,
+		Template:    slo.Template(),
+		Matrix:      slo.Matrix(),
 	}
 }
 
-func toObjective(objective *spec.Objective) Objective {
+func toObjective(objective *spec.Objective, windows []spec.Window) Objective {
+	allowedErrorRatio := spec.RoundRatio(1 - objective.Ratio())
+
+	var allowedBadTimes []AllowedBadTime
+	for _, w := range windows {
+		allowedBadTimes = append(allowedBadTimes, AllowedBadTime{
+			Window:   w.Name(),
+			Duration: w.Duration().String(),
+			BadTime:  spec.FormatDuration(float64(w.Duration()) * allowedErrorRatio),
+		})
+	}
+
+	ratios := objective.LabelRatios()
+	var labelRatios []LabelRatio
+	for _, value := range slices.Sorted(maps.Keys(ratios)) {
+		labelRatios = append(labelRatios, LabelRatio{
+			Value:             value,
+			Ratio:             ratios[value],
+			AllowedErrorRatio: spec.RoundRatio(1 - ratios[value]),
+		})
+	}
+
 	return Objective{
-		Ratio:  objective.Ratio(),
-		Window: toWindow(objective.Window()),
+		Ratio:             objective.Ratio(),
+		Window:            toWindow(objective.Window()),
+		AllowedErrorRatio: allowedErrorRatio,
+		RatioLabel:        objective.RatioLabel(),
+		LabelRatios:       labelRatios,
+		AllowedBadTimes:   allowedBadTimes,
 	}
 }
 
@@ -42,7 +84,22 @@ func toIndicator(indicator spec.Indicator) Indicator {
 	case *spec.PrometheusIndicator:
 		source = "prometheus"
 		query = &PrometheusQuery{
-			ErrorRatio: i.ErrorRatio(),
+			ErrorRatio:  i.ErrorRatio(),
+			TotalEvents: i.TotalEvents(),
+		}
+	case *spec.DatadogIndicator:
+		source = "datadog"
+		query = &DatadogQuery{
+			Good:  i.Good(),
+			Total: i.Total(),
+		}
+	case *spec.GoogleCloudMonitoringIndicator:
+		source = "googleCloudMonitoring"
+		query = &GoogleCloudMonitoringQuery{
+			Service:            i.Service(),
+			GoodServiceFilter:  i.GoodServiceFilter(),
+			BadServiceFilter:   i.BadServiceFilter(),
+			TotalServiceFilter: i.TotalServiceFilter(),
 		}
 	default:
 		panic("not implemented")
@@ -71,3 +128,73 @@ func toWindow(window spec.Window) Window {
 		Duration: window.Duration().String(),
 	}
 }
+
+func toAlert(objective *spec.Objective, alert spec.Alert) Alert {
+	var a Alert
+	switch alert := alert.(type) {
+	case *spec.BurnRateAlert:
+		a = Alert{
+			Type:                "burnRate",
+			ConsumedBudgetRatio: alert.ConsumedBudgetRatio(),
+		}
+		switch w := alert.Window().(type) {
+		case *spec.BurnRateAlertSingleWindow:
+			a.Windows = []Window{toWindow(w.Window())}
+		case *spec.BurnRateAlertMultiWindows:
+			a.Windows = []Window{toWindow(w.ShortWindow()), toWindow(w.LongWindow())}
+		}
+		if sloWindow := objective.Window(); sloWindow != nil {
+			a.BurnRateThreshold = alert.BurnRateThreshold(sloWindow)
+			a.TimeToExhaustion = spec.FormatDuration(float64(sloWindow.Duration()) / a.BurnRateThreshold)
+		}
+	case *spec.ErrorBudgetAlert:
+		a = Alert{
+			Type:                "errorBudget",
+			ConsumedBudgetRatio: alert.ConsumedBudgetRatio(),
+		}
+		if sloWindow := objective.Window(); sloWindow != nil {
+			a.Windows = []Window{toWindow(sloWindow)}
+		}
+	case *spec.ErrorBudgetForecastAlert:
+		a = Alert{
+			Type:     "errorBudgetForecast",
+			Lookback: alert.Lookback().String(),
+		}
+		if alert.Horizon() != 0 {
+			a.Horizon = alert.Horizon().String()
+		}
+		if sloWindow := objective.Window(); sloWindow != nil {
+			a.Windows = []Window{toWindow(sloWindow)}
+		}
+	case *spec.SLIAbsentAlert:
+		a = Alert{
+			Type: "sliAbsent",
+		}
+		if alert.For() != 0 {
+			a.For = alert.For().String()
+		}
+	default:
+		panic("unknown alert type")
+	}
+
+	if alert, ok := alert.(spec.GuardedAlert); ok {
+		a.MinEvents = alert.MinEvents()
+	}
+	a.Name = alert.Name()
+	a.Alerter = toAlerter(alert.Alerter())
+	return a
+}
+
+func toAlerter(alerter spec.Alerter) Alerter {
+	switch a := alerter.(type) {
+	case *spec.PrometheusAlerter:
+		return Alerter{
+			Type:        "prometheus",
+			Name:        a.Name(),
+			Labels:      a.Labels(),
+			Annotations: a.Annotations(),
+		}
+	default:
+		panic("not implemented")
+	}
+}
@@ -24,6 +24,14 @@ type SLO struct {
 	Objective Objective `yaml:"objective" json:"objective"`
 	// Indicator is the SLI for the SLO.
 	Indicator Indicator `yaml:"indicator" json:"indicator"`
+	// Alerts are alerts for the SLO.
+	Alerts []Alert `yaml:"alerts,omitempty" json:"alerts,omitempty"`
+	// Windows are windows used by the SLI and SLO.
+	Windows []Window `yaml:"windows,omitempty" json:"windows,omitempty"`
+	// Template is the name of the SLO template that the SLO is expanded from.
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+	// Matrix is the values of the matrix keys that the SLO is expanded with.
+	Matrix map[string]string `yaml:"matrix,omitempty" json:"matrix,omitempty"`
 }
 
 // Objective is a document for an SLO target.
@@ -32,6 +40,34 @@ type Objective struct {
 	Ratio float64 `yaml:"ratio" json:"ratio"`
 	// WindowRef is the window name that refers to a window defined in SLOConfig.Windows.
 	Window Window `yaml:"window" json:"window"`
+	// AllowedErrorRatio is the ratio of errors allowed by the objective (i.e., 1 - Ratio).
+	AllowedErrorRatio float64 `yaml:"allowedErrorRatio" json:"allowedErrorRatio"`
+	// RatioLabel is the name of the label by whose values the target ratio varies, if any.
+	RatioLabel string `yaml:"ratioLabel,omitempty" json:"ratioLabel,omitempty"`
+	// LabelRatios are the target ratios for the values of RatioLabel sorted by the values.
+	LabelRatios []LabelRatio `yaml:"labelRatios,omitempty" json:"labelRatios,omitempty"`
+	// AllowedBadTimes are the durations of complete outage allowed by the objective in each window.
+	AllowedBadTimes []AllowedBadTime `yaml:"allowedBadTimes,omitempty" json:"allowedBadTimes,omitempty"`
+}
+
+// LabelRatio is a document for the target ratio for a label value.
+type LabelRatio struct {
+	// Value is the label value.
+	Value string `yaml:"value" json:"value"`
+	// Ratio is the target ratio for the label value.
+	Ratio float64 `yaml:"ratio" json:"ratio"`
+	// AllowedErrorRatio is the ratio of errors allowed for the label value (i.e., 1 - Ratio).
+	AllowedErrorRatio float64 `yaml:"allowedErrorRatio" json:"allowedErrorRatio"`
+}
+
+// AllowedBadTime is a document for the duration of complete outage allowed by an objective in a window.
+type AllowedBadTime struct {
+	// Window is the name of the window.
+	Window string `yaml:"window" json:"window"`
+	// Duration is the duration of the window.
+	Duration string `yaml:"duration" json:"duration"`
+	// BadTime is the allowed duration of complete outage in the window.
+	BadTime string `yaml:"badTime" json:"badTime"`
 }
 
 // Indicator is a document for a service level indicator (SLI).
@@ -49,7 +85,8 @@ type Query interface {
 
 // PrometheusQuery is a document about the PromQL query in an indicator.
 type PrometheusQuery struct {
-	ErrorRatio string `yaml:"errorRatio,omitempty" json:"errorRatio,omitempty"`
+	ErrorRatio  string `yaml:"errorRatio,omitempty" json:"errorRatio,omitempty"`
+	TotalEvents string `yaml:"totalEvents,omitempty" json:"totalEvents,omitempty"`
 }
 
 var _ Query = &PrometheusQuery{}
@@ -58,6 +95,32 @@ func (q *PrometheusQuery) isQuery() bool {
 	return true
 }
 
+// DatadogQuery is a document about the Datadog metric queries in an indicator.
+type DatadogQuery struct {
+	Good  string `yaml:"good" json:"good"`
+	Total string `yaml:"total" json:"total"`
+}
+
+var _ Query = &DatadogQuery{}
+
+func (q *DatadogQuery) isQuery() bool {
+	return true
+}
+
+// GoogleCloudMonitoringQuery is a document about the Google Cloud Monitoring filters in an indicator.
+type GoogleCloudMonitoringQuery struct {
+	Service            string `yaml:"service,omitempty" json:"service,omitempty"`
+	GoodServiceFilter  string `yaml:"goodServiceFilter,omitempty" json:"goodServiceFilter,omitempty"`
+	BadServiceFilter   string `yaml:"badServiceFilter,omitempty" json:"badServiceFilter,omitempty"`
+	TotalServiceFilter string `yaml:"totalServiceFilter,omitempty" json:"totalServiceFilter,omitempty"`
+}
+
+var _ Query = &GoogleCloudMonitoringQuery{}
+
+func (q *GoogleCloudMonitoringQuery) isQuery() bool {
+	return true
+}
+
 // Window is a document for a window used by SLIs and SLOs.
 // Either the Rolling or Calendar field must be specified.
 type Window struct {
@@ -68,3 +131,41 @@ type Window struct {
 	// Duration is the duration of the window.
 	Duration string `yaml:"duration" json:"duration"`
 }
+
+// Alert is a document for an SLO alert.
+type Alert struct {
+	// Name is the name of the alert.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Type is the type of the alert: either burnRate, errorBudget, errorBudgetForecast or sliAbsent.
+	Type string `yaml:"type" json:"type"`
+	// ConsumedBudgetRatio is the alerting threshold based on the ratio of the consumed error budget (burn rate and error budget alerts only).
+	ConsumedBudgetRatio float64 `yaml:"consumedBudgetRatio" json:"consumedBudgetRatio"`
+	// Windows are the windows over which the alert is evaluated, the short one first.
+	Windows []Window `yaml:"windows,omitempty" json:"windows,omitempty"`
+	// BurnRateThreshold is the error budget burn rate at which the alert fires (burn rate alerts only).
+	BurnRateThreshold float64 `yaml:"burnRateThreshold,omitempty" json:"burnRateThreshold,omitempty"`
+	// TimeToExhaustion is the time until the whole error budget is consumed at the burn rate threshold (burn rate alerts only).
+	TimeToExhaustion string `yaml:"timeToExhaustion,omitempty" json:"timeToExhaustion,omitempty"`
+	// MinEvents is the minimum number of events over the (long) window for the alert to fire.
+	MinEvents int `yaml:"minEvents,omitempty" json:"minEvents,omitempty"`
+	// Lookback is the period over which the trend of the error budget is computed (error budget forecast alerts only).
+	Lookback string `yaml:"lookback,omitempty" json:"lookback,omitempty"`
+	// Horizon is how far ahead the error budget is forecast (error budget forecast alerts only).
+	Horizon string `yaml:"horizon,omitempty" json:"horizon,omitempty"`
+	// For is how long the SLI must be absent before the alert fires (SLI absence alerts only).
+	For string `yaml:"for,omitempty" json:"for,omitempty"`
+	// Alerter describes how the alert is implemented.
+	Alerter Alerter `yaml:"alerter" json:"alerter"`
+}
+
+// Alerter is a document for the implementation of an alert.
+type Alerter struct {
+	// Type is the type of the alerter (e.g., prometheus, ...)
+	Type string `yaml:"type" json:"type"`
+	// Name is the name of the alert notified by the alerter.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Labels are the labels attached to the alerts.
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// Annotations are the annotations attached to the alerts.
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
@@ -0,0 +1,60 @@
+package document
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/ajalab/slom/internal/print"
+)
+
+const (
+	outputMarkdown = "markdown"
+	outputHTML     = "html"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+var templateFileNames = map[string]string{
+	outputMarkdown: "templates/markdown.tmpl",
+	outputHTML:     "templates/html.tmpl",
+}
+
+var catalogTemplateFileNames = map[string]string{
+	outputMarkdown: "templates/catalog-markdown.tmpl",
+	outputHTML:     "templates/catalog-html.tmpl",
+}
+
+// NewPrinter returns a printer of documents for the output format, including "markdown" and "html".
+func NewPrinter(w io.Writer, output string) (print.Printer, error) {
+	switch output {
+	case outputMarkdown:
+		return print.NewGoTemplatePrinterFS(w, templates, templateFileNames[output])
+	case outputHTML:
+		return print.NewHTMLTemplatePrinterFS(w, templates, templateFileNames[output])
+	}
+	return print.NewPrinter(w, output)
+}
+
+// NewCatalogPrinter returns a printer of catalogs for the output format, including "markdown" and "html".
+func NewCatalogPrinter(w io.Writer, output string) (print.Printer, error) {
+	switch output {
+	case outputMarkdown:
+		return print.NewGoTemplatePrinterFS(w, templates, catalogTemplateFileNames[output])
+	case outputHTML:
+		// The catalog shares the style with the HTML document.
+		return print.NewHTMLTemplatePrinterFS(w, templates, catalogTemplateFileNames[output], templateFileNames[output])
+	}
+	return print.NewPrinter(w, output)
+}
+
+// Template returns the content of the built-in template for the output format.
+func Template(output string) ([]byte, error) {
+	fileName, ok := templateFileNames[output]
+	if !ok {
+		return nil, fmt.Errorf("no built-in template for format: %s", output)
+	}
+	return fs.ReadFile(templates, fileName)
+}
@@ -0,0 +1,555 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ajalab/slom/internal/alertmanager"
+	configseries "github.com/ajalab/slom/internal/config/series"
+	core "github.com/ajalab/slom/internal/config/spec/core/v1alpha"
+	configspec "github.com/ajalab/slom/internal/config/spec/native"
+	"github.com/ajalab/slom/internal/datadog"
+	"github.com/ajalab/slom/internal/document"
+	"github.com/ajalab/slom/internal/googlecloud"
+	"github.com/ajalab/slom/internal/grafana"
+	"github.com/ajalab/slom/internal/print"
+	"github.com/ajalab/slom/internal/prometheus/rule"
+	"github.com/ajalab/slom/internal/prometheus/series"
+	"github.com/ajalab/slom/internal/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecOptions is a set of options to load a spec file.
+type SpecOptions struct {
+	// Overlays are spec files applied to the spec file in order.
+	Overlays []string
+	// Vars are the values of the variables substituted in the spec file before the environment variables.
+	Vars map[string]string
+	// Naming is the naming scheme used if the spec file doesn't specify its own.
+	Naming *core.NamingConfig
+}
+
+// LoadSpec reads a spec config file and converts it into spec.
+func LoadSpec(specFileName string) (*spec.Spec, error) {
+	return loadSpec(specFileName, &SpecOptions{})
+}
+
+// loadSpec reads a spec config file with overlays and converts it into spec.
+// All the generators load spec files with it so that they name the SLOs and the series in the same way.
+func loadSpec(specFileName string, options *SpecOptions) (*spec.Spec, error) {
+	node, _, err := renderSpecConfig(specFileName, options)
+	if err != nil {
+		return nil, err
+	}
+
+	specConfig, err := configspec.DecodeSpecConfig(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s as spec config file: %w", specFileName, err)
+	}
+	if specConfig.Naming == nil {
+		specConfig.Naming = options.Naming
+	}
+	s, err := spec.ToSpec(specConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert a spec config %s into spec: %w", specFileName, err)
+	}
+	return s, nil
+}
+
+// SpecInputs returns the names of the files read to load a spec file, including its overlays.
+func SpecInputs(specFileName string, options *SpecOptions) ([]string, error) {
+	_, fileNames, err := renderSpecConfig(specFileName, options)
+	return fileNames, err
+}
+
+// SLOIds returns the IDs of the SLOs in a spec file, which identify the SLOs in the generated outputs.
+func SLOIds(specFileName string, options *SpecOptions) ([]string, error) {
+	s, err := loadSpec(specFileName, options)
+	if err != nil {
+		return nil, err
+	}
+	naming, err := rule.NewNaming(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, slo := range s.SLOs() {
+		ids = append(ids, naming.SLOId(s.Name(), slo.Name()))
+	}
+	return ids, nil
+}
+
+// renderSpecConfig reads a spec config file, applies the overlays and substitutes the variables.
+// It also returns the names of the files read.
+func renderSpecConfig(specFileName string, options *SpecOptions) (*yaml.Node, []string, error) {
+	node, fileNames, err := parseSpecConfigFile(specFileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, overlayFileName := range options.Overlays {
+		overlay, overlayFileNames, err := parseSpecConfigFile(overlayFileName)
+		if err != nil {
+			return nil, nil, err
+		}
+		fileNames = append(fileNames, overlayFileNames...)
+		if err := configspec.ApplyOverlay(node, overlay); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply overlay %s to %s: %w", overlayFileName, specFileName, err)
+		}
+	}
+
+	lookup := func(name string) (string, bool) {
+		if value, ok := options.Vars[name]; ok {
+			return value, true
+		}
+		return os.LookupEnv(name)
+	}
+	if err := configspec.SubstituteVariables(node, lookup); err != nil {
+		return nil, nil, fmt.Errorf("failed to substitute variables in %s: %w", specFileName, err)
+	}
+	return node, fileNames, nil
+}
+
+func parseSpecConfigFile(specFileName string) (*yaml.Node, []string, error) {
+	node, fileNames, err := configspec.ParseSpecConfigFile(specFileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load %s as spec config file: %w", specFileName, err)
+	}
+	return node, fileNames, nil
+}
+
+// Render writes a spec config file with the overlays applied and the variables substituted.
+func Render(w io.Writer, specFileName string, options *SpecOptions) error {
+	node, _, err := renderSpecConfig(specFileName, options)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+
+	return encoder.Encode(node)
+}
+
+// MigrateOptions is a set of options to migrate a spec file.
+type MigrateOptions struct {
+	// To is the schema version to migrate the spec file to. Defaults to the latest version.
+	To string
+	// Write rewrites the spec file instead of writing the migrated spec config to w.
+	Write bool
+}
+
+// Migrate rewrites a spec file from its schema version to a newer one, keeping the comments in it.
+func Migrate(w io.Writer, specFileName string, options *MigrateOptions) error {
+	specFile, err := os.Open(specFileName)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", specFileName, err)
+	}
+	node, err := configspec.ParseSpecConfigNode(specFile)
+	specFile.Close()
+	if err != nil {
+		return fmt.Errorf("failed to parse %s as spec config file: %w", specFileName, err)
+	}
+
+	to := options.To
+	if to == "" {
+		to = configspec.LatestVersion
+	}
+	if err := configspec.MigrateSpecConfig(node, to); err != nil {
+		return fmt.Errorf("failed to migrate %s: %w", specFileName, err)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	if options.Write {
+		info, err := os.Stat(specFileName)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(specFileName, buf.Bytes(), info.Mode().Perm())
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// DocumentOptions is a set of options to generate an SLO document.
+type DocumentOptions struct {
+	// Output is the output format of the document.
+	Output string
+	// Spec is the options to load the spec file.
+	Spec SpecOptions
+}
+
+// Document generates an SLO document from a spec file.
+func Document(w io.Writer, specFileName string, options *DocumentOptions) error {
+	s, err := loadSpec(specFileName, &options.Spec)
+	if err != nil {
+		return err
+	}
+	doc := document.ToDocument(s)
+
+	printer, err := document.NewPrinter(w, options.Output)
+	if err != nil {
+		return fmt.Errorf("failed to get a printer: %w", err)
+	}
+	defer printer.Close()
+
+	return printer.Print(doc)
+}
+
+// CatalogOptions is a set of options to generate an SLO catalog.
+type CatalogOptions struct {
+	// Output is the output format of the catalog.
+	Output string
+	// GroupBy is the spec label names used to group specs in the catalog.
+	GroupBy []string
+	// Spec is the options to load the spec files.
+	Spec SpecOptions
+}
+
+// Catalog generates an SLO catalog from spec files and directories of them.
+func Catalog(w io.Writer, specFileNames []string, options *CatalogOptions) error {
+	var specs []*spec.Spec
+	for _, specFileName := range specFileNames {
+		fileNames, err := expandSpecFileName(specFileName)
+		if err != nil {
+			return err
+		}
+		for _, fileName := range fileNames {
+			s, err := loadSpec(fileName, &options.Spec)
+			if err != nil {
+				return err
+			}
+			specs = append(specs, s)
+		}
+	}
+
+	catalog, err := document.ToCatalog(specs, options.GroupBy)
+	if err != nil {
+		return fmt.Errorf("failed to create a catalog: %w", err)
+	}
+
+	printer, err := document.NewCatalogPrinter(w, options.Output)
+	if err != nil {
+		return fmt.Errorf("failed to get a printer: %w", err)
+	}
+	defer printer.Close()
+
+	return printer.Print(catalog)
+}
+
+// expandSpecFileName returns spec files directly under fileName if it is a directory, or fileName itself otherwise.
+func expandSpecFileName(fileName string) ([]string, error) {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", fileName, err)
+	}
+	if !info.IsDir() {
+		return []string{fileName}, nil
+	}
+
+	entries, err := os.ReadDir(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", fileName, err)
+	}
+	var fileNames []string
+	for _, e := range entries {
+		if ext := filepath.Ext(e.Name()); !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			fileNames = append(fileNames, filepath.Join(fileName, e.Name()))
+		}
+	}
+	return fileNames, nil
+}
+
+// PrometheusRuleOptions is a set of options to generate Prometheus rules.
+type PrometheusRuleOptions struct {
+	// Type is the rule types to generate. Either "all" or "record".
+	Type string
+	// Output is the output format of the rules.
+	Output string
+	// RecordBurnRate enables recording rules for the error budget burn rate over each window.
+	RecordBurnRate bool
+	// RecordSuccessRatio enables recording rules for the success ratio over each window.
+	RecordSuccessRatio bool
+	// AggregateWindows derives the series over longer windows from those over the shortest rolling window.
+	AggregateWindows bool
+	// ShareRules records the series computed from the same indicator once for all SLOs in a spec.
+	ShareRules bool
+	// Spec is the options to load the spec file.
+	Spec SpecOptions
+}
+
+// PrometheusRule generates Prometheus rules from a spec file.
+func PrometheusRule(w io.Writer, specFileName string, options *PrometheusRuleOptions) error {
+	var alertEnabled bool
+	switch options.Type {
+	case "all":
+		alertEnabled = true
+	case "record":
+		alertEnabled = false
+	default:
+		return fmt.Errorf("either \"all\" or \"record\" must be specified as type")
+	}
+
+	s, err := loadSpec(specFileName, &options.Spec)
+	if err != nil {
+		return err
+	}
+
+	g := rule.NewRuleGenerator(&rule.RuleGeneratorOptions{
+		RecordBurnRate:     options.RecordBurnRate,
+		RecordSuccessRatio: options.RecordSuccessRatio,
+		AggregateWindows:   options.AggregateWindows,
+		ShareRules:         options.ShareRules,
+	})
+	if err := g.GenerateRecordingRules(s); err != nil {
+		return fmt.Errorf("failed to generate recording rule groups: %w", err)
+	}
+	if alertEnabled {
+		if err := g.GenerateAlertingRules(s); err != nil {
+			return fmt.Errorf("failed to generate alerting rule groups: %w", err)
+		}
+	}
+	ruleGroups := g.RuleGroups()
+
+	if options.Output == "prometheus" {
+		printer := print.NewYAMLPrinter(w)
+		defer printer.Close()
+
+		prometheusRuleGroups := ruleGroups.Prometheus()
+		return printer.Print(&prometheusRuleGroups)
+	}
+
+	printer, err := print.NewPrinter(w, options.Output)
+	if err != nil {
+		return fmt.Errorf("failed to get a printer: %w", err)
+	}
+	defer printer.Close()
+
+	return printer.Print(ruleGroups)
+}
+
+// GrafanaDashboardOptions is a set of options to generate a Grafana dashboard.
+type GrafanaDashboardOptions struct {
+	// Output is the output format of the dashboard.
+	Output string
+	// ShareRules must be set if the rules are generated with PrometheusRuleOptions.ShareRules.
+	ShareRules bool
+	// AggregateWindows must be set if the rules are generated with PrometheusRuleOptions.AggregateWindows.
+	AggregateWindows bool
+	// Spec is the options to load the spec file.
+	Spec SpecOptions
+}
+
+// GrafanaDashboard generates a Grafana dashboard from a spec file.
+func GrafanaDashboard(w io.Writer, specFileName string, options *GrafanaDashboardOptions) error {
+	s, err := loadSpec(specFileName, &options.Spec)
+	if err != nil {
+		return err
+	}
+
+	dashboard, err := grafana.NewDashboardGenerator(&grafana.DashboardGeneratorOptions{
+		ShareRules:       options.ShareRules,
+		AggregateWindows: options.AggregateWindows,
+	}).Generate(s)
+	if err != nil {
+		return fmt.Errorf("failed to generate a dashboard: %w", err)
+	}
+
+	printer, err := print.NewPrinter(w, options.Output)
+	if err != nil {
+		return fmt.Errorf("failed to get a printer: %w", err)
+	}
+	defer printer.Close()
+
+	return printer.Print(dashboard)
+}
+
+// AlertmanagerOptions is a set of options to generate an Alertmanager configuration.
+type AlertmanagerOptions struct {
+	// Output is the output format of the configuration.
+	Output string
+	// DefaultReceiver is the receiver of the route for the alerts of the spec.
+	DefaultReceiver string
+	// SeverityLabel is the label name that tells the severity of alerts.
+	SeverityLabel string
+	// Receivers maps values of the severity label to receivers.
+	Receivers map[string]string
+	// Spec is the options to load the spec file.
+	Spec SpecOptions
+}
+
+// Alertmanager generates an Alertmanager configuration fragment for the alerts generated from a spec file.
+func Alertmanager(w io.Writer, specFileName string, options *AlertmanagerOptions) error {
+	s, err := loadSpec(specFileName, &options.Spec)
+	if err != nil {
+		return err
+	}
+
+	config, err := alertmanager.NewConfigGenerator(&alertmanager.ConfigGeneratorOptions{
+		DefaultReceiver: options.DefaultReceiver,
+		SeverityLabel:   options.SeverityLabel,
+		Receivers:       options.Receivers,
+	}).Generate(s)
+	if err != nil {
+		return fmt.Errorf("failed to generate an Alertmanager configuration: %w", err)
+	}
+
+	printer, err := print.NewPrinter(w, options.Output)
+	if err != nil {
+		return fmt.Errorf("failed to get a printer: %w", err)
+	}
+	defer printer.Close()
+
+	return printer.Print(config)
+}
+
+// GrafanaSLOOptions is a set of options to generate Grafana SLOs.
+type GrafanaSLOOptions struct {
+	// Output is the output format of the SLOs.
+	Output string
+	// DestinationDatasourceUID is the UID of the data source where Grafana writes recording rules.
+	DestinationDatasourceUID string
+	// Spec is the options to load the spec file.
+	Spec SpecOptions
+}
+
+// GrafanaSLO generates SLOs in the Grafana SLO API from a spec file.
+func GrafanaSLO(w io.Writer, specFileName string, options *GrafanaSLOOptions) error {
+	s, err := loadSpec(specFileName, &options.Spec)
+	if err != nil {
+		return err
+	}
+
+	slos, err := grafana.NewSLOGenerator(&grafana.SLOGeneratorOptions{
+		DestinationDatasourceUID: options.DestinationDatasourceUID,
+	}).Generate(s)
+	if err != nil {
+		return fmt.Errorf("failed to generate Grafana SLOs: %w", err)
+	}
+
+	return printVendorSLOs(w, options.Output, slos)
+}
+
+// DatadogSLOOptions is a set of options to generate Datadog SLOs.
+type DatadogSLOOptions struct {
+	// Output is the output format of the SLOs.
+	Output string
+	// Spec is the options to load the spec file.
+	Spec SpecOptions
+}
+
+// DatadogSLO generates Datadog SLOs and SLO monitors from a spec file.
+func DatadogSLO(w io.Writer, specFileName string, options *DatadogSLOOptions) error {
+	s, err := loadSpec(specFileName, &options.Spec)
+	if err != nil {
+		return err
+	}
+
+	slos, err := datadog.NewSLOGenerator().Generate(s)
+	if err != nil {
+		return fmt.Errorf("failed to generate Datadog SLOs: %w", err)
+	}
+
+	return printVendorSLOs(w, options.Output, slos)
+}
+
+// GoogleCloudMonitoringSLOOptions is a set of options to generate Google Cloud Monitoring SLOs.
+type GoogleCloudMonitoringSLOOptions struct {
+	// Output is the output format of the SLOs.
+	Output string
+	// Spec is the options to load the spec file.
+	Spec SpecOptions
+}
+
+// GoogleCloudMonitoringSLO generates Google Cloud Monitoring SLOs and alert policies from a spec file.
+func GoogleCloudMonitoringSLO(w io.Writer, specFileName string, options *GoogleCloudMonitoringSLOOptions) error {
+	s, err := loadSpec(specFileName, &options.Spec)
+	if err != nil {
+		return err
+	}
+
+	slos, err := googlecloud.NewSLOGenerator().Generate(s)
+	if err != nil {
+		return fmt.Errorf("failed to generate Google Cloud Monitoring SLOs: %w", err)
+	}
+
+	return printVendorSLOs(w, options.Output, slos)
+}
+
+func printVendorSLOs(w io.Writer, output string, slos any) error {
+	printer, err := print.NewPrinter(w, output)
+	if err != nil {
+		return fmt.Errorf("failed to get a printer: %w", err)
+	}
+	defer printer.Close()
+
+	return printer.Print(slos)
+}
+
+// PrometheusSeriesOptions is a set of options to generate Prometheus time series.
+type PrometheusSeriesOptions struct {
+	// Output is the output format of the series.
+	Output string
+	// RuleFiles are the rule file names referred from rule unit tests.
+	RuleFiles []string
+	// Start overrides the start time in the series file if not zero.
+	Start time.Time
+	// End overrides the end time in the series file if not zero.
+	End time.Time
+	// Interval overrides the interval in the series file if not zero.
+	Interval time.Duration
+}
+
+// PrometheusSeries generates Prometheus time series from a series file.
+func PrometheusSeries(w io.Writer, seriesFileName string, options *PrometheusSeriesOptions) error {
+	file, err := os.Open(seriesFileName)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", seriesFileName, err)
+	}
+	defer file.Close()
+
+	seriesConfigParser := configseries.SeriesConfigParser{
+		Start:    options.Start,
+		End:      options.End,
+		Interval: options.Interval,
+	}
+	seriesConfig, err := seriesConfigParser.Parse(file)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", seriesFileName, err)
+	}
+
+	g, err := series.NewSeriesSetGenerator(seriesConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create a generator: %w", err)
+	}
+
+	switch options.Output {
+	case "openmetrics":
+		return g.GenerateOpenMetrics(w)
+	case "unittest":
+		return g.GenerateUnitTest(options.RuleFiles, w)
+	}
+
+	printer, err := print.NewPrinter(w, options.Output)
+	if err != nil {
+		return fmt.Errorf("failed to get a printer: %w", err)
+	}
+	defer printer.Close()
+
+	return printer.Print(g.Generate())
+}
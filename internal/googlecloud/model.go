@@ -0,0 +1,67 @@
+package googlecloud
+
+// SLOWithAlertPolicies is a Cloud Monitoring service level objective with the alert policies that alert on it.
+type SLOWithAlertPolicies struct {
+	// ServiceLevelObjectiveId is the ID given to the SLO when it is created.
+	ServiceLevelObjectiveId string `json:"serviceLevelObjectiveId"`
+	// Parent is the resource name of the service that the SLO belongs to.
+	Parent                string                 `json:"parent"`
+	ServiceLevelObjective *ServiceLevelObjective `json:"serviceLevelObjective"`
+	AlertPolicies         []*AlertPolicy         `json:"alertPolicies"`
+}
+
+// ServiceLevelObjective is a ServiceLevelObjective resource in the Cloud Monitoring API.
+type ServiceLevelObjective struct {
+	DisplayName           string                 `json:"displayName"`
+	Goal                  float64                `json:"goal"`
+	RollingPeriod         string                 `json:"rollingPeriod,omitempty"`
+	CalendarPeriod        string                 `json:"calendarPeriod,omitempty"`
+	ServiceLevelIndicator *ServiceLevelIndicator `json:"serviceLevelIndicator"`
+	UserLabels            map[string]string      `json:"userLabels,omitempty"`
+}
+
+// ServiceLevelIndicator is the SLI of a service level objective.
+type ServiceLevelIndicator struct {
+	RequestBased *RequestBasedSli `json:"requestBased"`
+}
+
+// RequestBasedSli is an SLI based on the counts of good and bad requests.
+type RequestBasedSli struct {
+	GoodTotalRatio *TimeSeriesRatio `json:"goodTotalRatio"`
+}
+
+// TimeSeriesRatio is a ratio of time series selected by monitoring filters.
+type TimeSeriesRatio struct {
+	GoodServiceFilter  string `json:"goodServiceFilter,omitempty"`
+	BadServiceFilter   string `json:"badServiceFilter,omitempty"`
+	TotalServiceFilter string `json:"totalServiceFilter,omitempty"`
+}
+
+// AlertPolicy is an AlertPolicy resource in the Cloud Monitoring API.
+type AlertPolicy struct {
+	DisplayName   string                    `json:"displayName"`
+	Documentation *AlertPolicyDocumentation `json:"documentation,omitempty"`
+	UserLabels    map[string]string         `json:"userLabels,omitempty"`
+	Conditions    []*AlertPolicyCondition   `json:"conditions"`
+	Combiner      string                    `json:"combiner"`
+}
+
+// AlertPolicyDocumentation is the documentation attached to notifications of an alert policy.
+type AlertPolicyDocumentation struct {
+	Content  string `json:"content"`
+	MimeType string `json:"mimeType"`
+}
+
+// AlertPolicyCondition is a condition of an alert policy.
+type AlertPolicyCondition struct {
+	DisplayName        string                         `json:"displayName"`
+	ConditionThreshold *AlertPolicyConditionThreshold `json:"conditionThreshold"`
+}
+
+// AlertPolicyConditionThreshold is a condition that compares time series with a threshold.
+type AlertPolicyConditionThreshold struct {
+	Filter         string  `json:"filter"`
+	Comparison     string  `json:"comparison"`
+	ThresholdValue float64 `json:"thresholdValue"`
+	Duration       string  `json:"duration"`
+}
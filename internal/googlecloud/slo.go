@@ -0,0 +1,201 @@
+package googlecloud
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"time"
+
+	"github.com/ajalab/slom/internal/prometheus/rule"
+	"github.com/ajalab/slom/internal/spec"
+)
+
+const day = 24 * time.Hour
+
+// maxRollingPeriod is the longest rolling period of SLOs supported by Cloud Monitoring.
+const maxRollingPeriod = 30 * day
+
+// calendarPeriods are the calendar periods of SLOs supported by Cloud Monitoring.
+var calendarPeriods = map[time.Duration]string{
+	day:      "DAY",
+	7 * day:  "WEEK",
+	14 * day: "FORTNIGHT",
+}
+
+// SLOGenerator generates Cloud Monitoring SLOs and alert policies from specs.
+type SLOGenerator struct{}
+
+// NewSLOGenerator creates an SLOGenerator.
+func NewSLOGenerator() *SLOGenerator {
+	return &SLOGenerator{}
+}
+
+// Generate generates a request-based SLO and its alert policies for each SLO in s.
+func (g *SLOGenerator) Generate(s *spec.Spec) ([]*SLOWithAlertPolicies, error) {
+	naming, err := rule.NewNaming(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var slos []*SLOWithAlertPolicies
+	for _, slo := range s.SLOs() {
+		gcmSLO, err := g.generateSLO(naming, s, slo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate a Cloud Monitoring SLO for SLO %s: %w", slo.Name(), err)
+		}
+		slos = append(slos, gcmSLO)
+	}
+	return slos, nil
+}
+
+func (g *SLOGenerator) generateSLO(naming *rule.Naming, s *spec.Spec, slo *spec.SLO) (*SLOWithAlertPolicies, error) {
+	indicator, ok := slo.Indicator().(*spec.GoogleCloudMonitoringIndicator)
+	if !ok {
+		return nil, fmt.Errorf("only googleCloudMonitoring indicator is supported")
+	}
+	if indicator.Service() == "" {
+		return nil, fmt.Errorf("service must be specified in googleCloudMonitoring indicator")
+	}
+
+	if slo.Objective().RatioLabel() != "" {
+		return nil, fmt.Errorf("objectives by label values are not supported")
+	}
+
+	objective := &ServiceLevelObjective{
+		DisplayName: s.Name() + " " + slo.Name(),
+		Goal:        slo.Objective().Ratio(),
+		ServiceLevelIndicator: &ServiceLevelIndicator{
+			RequestBased: &RequestBasedSli{
+				GoodTotalRatio: &TimeSeriesRatio{
+					GoodServiceFilter:  indicator.GoodServiceFilter(),
+					BadServiceFilter:   indicator.BadServiceFilter(),
+					TotalServiceFilter: indicator.TotalServiceFilter(),
+				},
+			},
+		},
+		UserLabels: userLabels(naming, s, slo),
+	}
+
+	switch w := slo.Objective().Window().(type) {
+	case *spec.RollingWindow:
+		d := time.Duration(w.Duration())
+		if d%day != 0 || d > maxRollingPeriod {
+			return nil, fmt.Errorf("rolling window %s must be whole days no longer than 30 days", w.Duration())
+		}
+		objective.RollingPeriod = formatDuration(w.Duration())
+	case *spec.CalendarWindow:
+		period, ok := calendarPeriods[time.Duration(w.Duration())]
+		if !ok {
+			return nil, fmt.Errorf("calendar window %s is not supported. Either 1d, 1w or 2w must be used", w.Duration())
+		}
+		objective.CalendarPeriod = period
+	default:
+		return nil, fmt.Errorf("the objective must have a window")
+	}
+
+	id := naming.SLOId(s.Name(), slo.Name())
+	name := indicator.Service() + "/serviceLevelObjectives/" + id
+
+	policies := []*AlertPolicy{}
+	for _, a := range slo.Alerts() {
+		policy, err := generateAlertPolicy(s, slo, a, name)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return &SLOWithAlertPolicies{
+		ServiceLevelObjectiveId: id,
+		Parent:                  indicator.Service(),
+		ServiceLevelObjective:   objective,
+		AlertPolicies:           policies,
+	}, nil
+}
+
+func generateAlertPolicy(s *spec.Spec, slo *spec.SLO, a spec.Alert, sloName string) (*AlertPolicy, error) {
+	if a, ok := a.(spec.GuardedAlert); ok && a.MinEvents() > 0 {
+		return nil, fmt.Errorf("minEvents is not supported")
+	}
+
+	policy := &AlertPolicy{
+		DisplayName: fmt.Sprintf("%s %s", s.Name(), slo.Name()),
+		Combiner:    "AND",
+	}
+
+	switch a := a.(type) {
+	case *spec.BurnRateAlert:
+		policy.DisplayName += " burn rate"
+		threshold := a.BurnRateThreshold(slo.Objective().Window())
+		var windows []spec.Window
+		switch w := a.Window().(type) {
+		case *spec.BurnRateAlertSingleWindow:
+			windows = []spec.Window{w.Window()}
+		case *spec.BurnRateAlertMultiWindows:
+			windows = []spec.Window{w.LongWindow(), w.ShortWindow()}
+		}
+		for _, w := range windows {
+			policy.Conditions = append(policy.Conditions, &AlertPolicyCondition{
+				DisplayName: fmt.Sprintf("Burn rate over %s exceeds %g", w.Duration(), threshold),
+				ConditionThreshold: &AlertPolicyConditionThreshold{
+					Filter:         fmt.Sprintf("select_slo_burn_rate(\"%s\", \"%s\")", sloName, formatDuration(w.Duration())),
+					Comparison:     "COMPARISON_GT",
+					ThresholdValue: threshold,
+					Duration:       "0s",
+				},
+			})
+		}
+	case *spec.ErrorBudgetAlert:
+		policy.DisplayName += " error budget"
+		// select_slo_budget_fraction evaluates the fraction of the error budget remaining.
+		remaining := math.Round((1-a.ConsumedBudgetRatio())*1e9) / 1e9
+		policy.Conditions = []*AlertPolicyCondition{{
+			DisplayName: fmt.Sprintf("Remaining error budget falls below %g", remaining),
+			ConditionThreshold: &AlertPolicyConditionThreshold{
+				Filter:         fmt.Sprintf("select_slo_budget_fraction(\"%s\")", sloName),
+				Comparison:     "COMPARISON_LT",
+				ThresholdValue: remaining,
+				Duration:       "0s",
+			},
+		}}
+	case *spec.ErrorBudgetForecastAlert:
+		return nil, fmt.Errorf("error budget forecast alerts are not supported")
+	case *spec.SLIAbsentAlert:
+		return nil, fmt.Errorf("SLI absence alerts are not supported")
+	default:
+		return nil, fmt.Errorf("unknown alert type: %T", a)
+	}
+
+	if a.Name() != "" {
+		policy.DisplayName = a.Name()
+	}
+	if alerter, ok := a.Alerter().(*spec.PrometheusAlerter); ok {
+		if a.Name() == "" && alerter.Name() != "" {
+			policy.DisplayName = alerter.Name()
+		}
+		if len(alerter.Labels()) > 0 {
+			policy.UserLabels = alerter.Labels()
+		}
+		if description := alerter.Annotations()["description"]; description != "" {
+			policy.Documentation = &AlertPolicyDocumentation{
+				Content:  description,
+				MimeType: "text/markdown",
+			}
+		}
+	}
+	return policy, nil
+}
+
+func userLabels(naming *rule.Naming, s *spec.Spec, slo *spec.SLO) map[string]string {
+	labels := map[string]string{}
+	maps.Copy(labels, s.Labels())
+	maps.Copy(labels, slo.Labels())
+	labels[naming.LabelNameSpec()] = s.Name()
+	labels[naming.LabelNameSLO()] = slo.Name()
+	return labels
+}
+
+// formatDuration formats d in seconds, which is the JSON representation of durations in Google Cloud APIs.
+func formatDuration(d spec.Duration) string {
+	return fmt.Sprintf("%ds", time.Duration(d)/time.Second)
+}
@@ -0,0 +1,382 @@
+package grafana
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ajalab/slom/internal/prometheus/rule"
+	"github.com/ajalab/slom/internal/spec"
+)
+
+const (
+	schemaVersion = 39
+
+	variableNameDatasource = "datasource"
+
+	panelHeight    = 8
+	panelWidthHalf = 12
+	panelWidthFull = 24
+
+	// uidMaxLength is the maximum length of dashboard and SLO UIDs accepted by Grafana.
+	uidMaxLength = 40
+)
+
+// datasource refers to the Prometheus data source chosen by the datasource variable,
+// so that dashboards can be imported without knowing data sources in a Grafana instance.
+var datasource = &Datasource{
+	Type: "prometheus",
+	UID:  "${" + variableNameDatasource + "}",
+}
+
+// DashboardGeneratorOptions is a set of options for DashboardGenerator.
+type DashboardGeneratorOptions struct {
+	// ShareRules selects the error rates shared by SLOs with the same indicator.
+	ShareRules bool
+	// AggregateWindows selects the series derived from those over the shortest rolling window.
+	AggregateWindows bool
+}
+
+// DashboardGenerator generates Grafana dashboards from specs.
+type DashboardGenerator struct {
+	options     *DashboardGeneratorOptions
+	nextPanelID int
+	y           int
+}
+
+// NewDashboardGenerator creates a DashboardGenerator.
+func NewDashboardGenerator(options *DashboardGeneratorOptions) *DashboardGenerator {
+	if options == nil {
+		options = &DashboardGeneratorOptions{}
+	}
+	return &DashboardGenerator{options: options}
+}
+
+// Generate generates a dashboard that shows the series recorded by the rules generated from s.
+func (g *DashboardGenerator) Generate(s *spec.Spec) (*Dashboard, error) {
+	naming, err := rule.NewNaming(s)
+	if err != nil {
+		return nil, err
+	}
+	g.nextPanelID = 1
+	g.y = 0
+
+	var levels []string
+	var panels []*Panel
+	for _, slo := range s.SLOs() {
+		indicator, ok := slo.Indicator().(*spec.PrometheusIndicator)
+		if !ok {
+			return nil, fmt.Errorf("only prometheus indicator is supported: SLO %s", slo.Name())
+		}
+		for _, level := range indicator.Level() {
+			if !slices.Contains(levels, level) {
+				levels = append(levels, level)
+			}
+		}
+
+		sloPanels, err := g.sloPanels(naming, s.Name(), slo, indicator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate panels for SLO %s: %w", slo.Name(), err)
+		}
+		panels = append(panels, sloPanels...)
+	}
+
+	return &Dashboard{
+		UID:           uid(s.Name()),
+		Title:         "SLO: " + s.Name(),
+		Tags:          []string{"slom"},
+		Timezone:      "browser",
+		SchemaVersion: schemaVersion,
+		Editable:      true,
+		Refresh:       "1m",
+		Time:          TimeRange{From: "now-7d", To: "now"},
+		Templating:    Templating{List: variables(naming, s, levels)},
+		Annotations:   Annotations{List: annotations(naming, s)},
+		Panels:        panels,
+	}, nil
+}
+
+func (g *DashboardGenerator) sloPanels(
+	naming *rule.Naming,
+	specName string,
+	slo *spec.SLO,
+	indicator *spec.PrometheusIndicator,
+) ([]*Panel, error) {
+	id := naming.SLOId(specName, slo.Name())
+	selector := seriesSelector(naming.LabelNameId(), id, indicator.Level())
+	// Error rates are shared by SLOs with the same indicator and labeled with the indicator instead of the SLO.
+	errorRateSelector := selector
+	if g.options.ShareRules {
+		indicatorId, err := rule.IndicatorId(slo, &rule.RuleGeneratorOptions{
+			AggregateWindows: g.options.AggregateWindows,
+			ShareRules:       true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		errorRateSelector = seriesSelector(naming.LabelNameIndicator(), indicatorId, indicator.Level())
+	}
+	legend := legendFormat(indicator.Level())
+	objective := slo.Objective()
+
+	panels := []*Panel{g.row(fmt.Sprintf("SLO: %s", slo.Name()))}
+
+	// SLI over each window compared with the objective.
+	var sliTargets []*Target
+	for _, w := range slo.Windows() {
+		sliTargets = append(sliTargets, &Target{
+			Expr:         fmt.Sprintf("1 - %s%s", naming.MetricNameErrorRate(indicator.Level(), w.Duration()), errorRateSelector),
+			LegendFormat: joinLegend(legend, w.Duration().String()),
+		})
+	}
+	sliTargets = append(sliTargets, &Target{
+		Expr:         fmt.Sprintf("%s{%s=\"%s\"}", naming.MetricNameSLO(), naming.LabelNameId(), id),
+		LegendFormat: "objective",
+	})
+	sli := g.timeSeries("SLI", sliTargets, 0, panelWidthHalf)
+	sli.Description = fmt.Sprintf("Ratio of good events over each window. The objective is %g.", objective.Ratio())
+	if label := objective.RatioLabel(); label != "" {
+		sli.Description = fmt.Sprintf("Ratio of good events over each window. The objective varies by %s and is %g for the other values.", label, objective.Ratio())
+	}
+	sli.FieldConfig = fieldConfig("percentunit", nil, nil)
+	panels = append(panels, sli)
+
+	// Remaining error budget over the SLO window.
+	if sloWindow := objective.Window(); sloWindow != nil {
+		budget := g.timeSeries("Remaining error budget", []*Target{{
+			Expr:         naming.MetricNameErrorBudget(indicator.Level(), sloWindow.Duration()) + selector,
+			LegendFormat: joinLegend(legend, sloWindow.Duration().String()),
+		}}, panelWidthHalf, panelWidthHalf)
+		budget.Description = fmt.Sprintf("Ratio of the error budget remaining over the %s window.", sloWindow.Duration())
+		zero := 0.0
+		budget.FieldConfig = fieldConfig("percentunit", thresholds(&zero, "green"), nil)
+		panels = append(panels, budget)
+	}
+	g.nextRow(panelHeight)
+
+	// Burn rate against the threshold of each burn rate alert.
+	x := 0
+	for _, a := range slo.Alerts() {
+		a, ok := a.(*spec.BurnRateAlert)
+		if !ok || objective.Window() == nil {
+			continue
+		}
+
+		var windows []spec.Window
+		switch w := a.Window().(type) {
+		case *spec.BurnRateAlertSingleWindow:
+			windows = []spec.Window{w.Window()}
+		case *spec.BurnRateAlertMultiWindows:
+			windows = []spec.Window{w.ShortWindow(), w.LongWindow()}
+		}
+
+		var targets []*Target
+		var durations []string
+		for _, w := range windows {
+			durations = append(durations, w.Duration().String())
+			targets = append(targets, &Target{
+				Expr:         naming.BurnRateExpr(id, objective, naming.MetricNameErrorRate(indicator.Level(), w.Duration())+errorRateSelector),
+				LegendFormat: joinLegend(legend, w.Duration().String()),
+			})
+		}
+
+		threshold := a.BurnRateThreshold(objective.Window())
+		title := "Burn rate"
+		if name := alertName(a); name != "" {
+			title += ": " + name
+		}
+		title += " (" + strings.Join(durations, ", ") + ")"
+		burnRate := g.timeSeries(title, targets, x, panelWidthHalf)
+		burnRate.Description = fmt.Sprintf(
+			"Error budget burn rate. The alert fires when the burn rate exceeds %g (%g of the error budget consumed).",
+			threshold,
+			a.ConsumedBudgetRatio(),
+		)
+		burnRate.FieldConfig = fieldConfig("", thresholds(&threshold, "red"), map[string]any{
+			"thresholdsStyle": map[string]any{"mode": "line+area"},
+		})
+		panels = append(panels, burnRate)
+
+		if x += panelWidthHalf; x >= panelWidthFull {
+			x = 0
+			g.nextRow(panelHeight)
+		}
+	}
+	if x > 0 {
+		g.nextRow(panelHeight)
+	}
+
+	return panels, nil
+}
+
+func (g *DashboardGenerator) row(title string) *Panel {
+	collapsed := false
+	p := &Panel{
+		ID:        g.panelID(),
+		Type:      "row",
+		Title:     title,
+		GridPos:   GridPos{H: 1, W: panelWidthFull, X: 0, Y: g.y},
+		Collapsed: &collapsed,
+		Panels:    []*Panel{},
+	}
+	g.nextRow(1)
+	return p
+}
+
+func (g *DashboardGenerator) timeSeries(title string, targets []*Target, x int, width int) *Panel {
+	for i, t := range targets {
+		t.RefID = string(rune('A' + i))
+		t.Datasource = datasource
+	}
+
+	return &Panel{
+		ID:         g.panelID(),
+		Type:       "timeseries",
+		Title:      title,
+		GridPos:    GridPos{H: panelHeight, W: width, X: x, Y: g.y},
+		Datasource: datasource,
+		Targets:    targets,
+		Options: map[string]any{
+			"legend":  map[string]any{"displayMode": "list", "placement": "bottom", "showLegend": true},
+			"tooltip": map[string]any{"mode": "multi", "sort": "none"},
+		},
+	}
+}
+
+func (g *DashboardGenerator) panelID() int {
+	id := g.nextPanelID
+	g.nextPanelID++
+	return id
+}
+
+func (g *DashboardGenerator) nextRow(height int) {
+	g.y += height
+}
+
+func variables(naming *rule.Naming, s *spec.Spec, levels []string) []*Variable {
+	vs := []*Variable{{
+		Name:  variableNameDatasource,
+		Label: "Data source",
+		Type:  "datasource",
+		Query: "prometheus",
+	}}
+
+	for _, level := range levels {
+		vs = append(vs, &Variable{
+			Name:       level,
+			Type:       "query",
+			Datasource: datasource,
+			Query: VariableQuery{
+				Query: fmt.Sprintf("label_values({%s=\"%s\"}, %s)", naming.LabelNameSpec(), s.Name(), level),
+				RefID: "PrometheusVariableQueryEditor-VariableQuery",
+			},
+			// Refresh the values on time range change.
+			Refresh:    2,
+			Multi:      true,
+			IncludeAll: true,
+			AllValue:   ".*",
+			Sort:       1,
+		})
+	}
+	return vs
+}
+
+func annotations(naming *rule.Naming, s *spec.Spec) []*Annotation {
+	return []*Annotation{
+		{
+			Name:       "Annotations & Alerts",
+			Datasource: &Datasource{Type: "grafana", UID: "-- Grafana --"},
+			Enable:     true,
+			Hide:       true,
+			IconColor:  "rgba(0, 211, 255, 1)",
+			BuiltIn:    1,
+			Type:       "dashboard",
+		},
+		{
+			Name:        "SLO alerts",
+			Datasource:  datasource,
+			Enable:      true,
+			IconColor:   "red",
+			Expr:        fmt.Sprintf("ALERTS{%s=\"%s\", alertstate=\"firing\"}", naming.LabelNameSpec(), s.Name()),
+			Step:        "60s",
+			TitleFormat: "{{alertname}}",
+			TextFormat:  "{{" + naming.LabelNameSLO() + "}}",
+			TagKeys:     naming.LabelNameSLO(),
+		},
+	}
+}
+
+func fieldConfig(unit string, t *Thresholds, custom map[string]any) *FieldConfig {
+	return &FieldConfig{
+		Defaults: FieldConfigDefaults{
+			Unit:       unit,
+			Thresholds: t,
+			Custom:     custom,
+		},
+		Overrides: []any{},
+	}
+}
+
+// thresholds returns thresholds that color values above threshold with color.
+// Below threshold, values are colored red if color is green, otherwise green.
+func thresholds(threshold *float64, color string) *Thresholds {
+	base := "green"
+	if color == "green" {
+		base = "red"
+	}
+	return &Thresholds{
+		Mode: "absolute",
+		Steps: []*ThresholdStep{
+			{Color: base, Value: nil},
+			{Color: color, Value: threshold},
+		},
+	}
+}
+
+// seriesSelector returns a selector of the series labeled with value filtered by the template variables for the levels.
+func seriesSelector(labelName string, value string, levels []string) string {
+	matchers := []string{fmt.Sprintf("%s=\"%s\"", labelName, value)}
+	for _, level := range levels {
+		matchers = append(matchers, fmt.Sprintf("%s=~\"$%s\"", level, level))
+	}
+	return "{" + strings.Join(matchers, ", ") + "}"
+}
+
+func legendFormat(levels []string) string {
+	var ls []string
+	for _, level := range levels {
+		ls = append(ls, "{{"+level+"}}")
+	}
+	return strings.Join(ls, " ")
+}
+
+func joinLegend(legend string, window string) string {
+	if legend == "" {
+		return window
+	}
+	return legend + " " + window
+}
+
+func alertName(a spec.Alert) string {
+	if a.Name() != "" {
+		return a.Name()
+	}
+	if alerter, ok := a.Alerter().(*spec.PrometheusAlerter); ok {
+		return alerter.Name()
+	}
+	return ""
+}
+
+// uid returns a UID of a dashboard or an SLO for name, which is stable across generations
+// so that importing it again overwrites the previous one.
+func uid(name string) string {
+	id := "slom-" + name
+	if len(id) <= uidMaxLength {
+		return id
+	}
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:8]
+	return id[:uidMaxLength-len(hash)-1] + "-" + hash
+}
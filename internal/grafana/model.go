@@ -0,0 +1,133 @@
+package grafana
+
+// Dashboard is a Grafana dashboard in the JSON model that can be imported into Grafana.
+type Dashboard struct {
+	UID           string      `json:"uid"`
+	Title         string      `json:"title"`
+	Tags          []string    `json:"tags"`
+	Timezone      string      `json:"timezone"`
+	SchemaVersion int         `json:"schemaVersion"`
+	Editable      bool        `json:"editable"`
+	Refresh       string      `json:"refresh"`
+	Time          TimeRange   `json:"time"`
+	Templating    Templating  `json:"templating"`
+	Annotations   Annotations `json:"annotations"`
+	Panels        []*Panel    `json:"panels"`
+}
+
+// TimeRange is the default time range of a dashboard.
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating is the list of template variables of a dashboard.
+type Templating struct {
+	List []*Variable `json:"list"`
+}
+
+// Variable is a template variable of a dashboard.
+type Variable struct {
+	Name       string      `json:"name"`
+	Label      string      `json:"label,omitempty"`
+	Type       string      `json:"type"`
+	Query      any         `json:"query"`
+	Datasource *Datasource `json:"datasource,omitempty"`
+	Refresh    int         `json:"refresh,omitempty"`
+	Multi      bool        `json:"multi"`
+	IncludeAll bool        `json:"includeAll"`
+	AllValue   string      `json:"allValue,omitempty"`
+	Sort       int         `json:"sort,omitempty"`
+}
+
+// VariableQuery is the query of a query variable.
+type VariableQuery struct {
+	Query string `json:"query"`
+	RefID string `json:"refId"`
+}
+
+// Datasource is a reference to a data source.
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// Annotations is the list of annotation queries of a dashboard.
+type Annotations struct {
+	List []*Annotation `json:"list"`
+}
+
+// Annotation is an annotation query that marks events on panels.
+type Annotation struct {
+	Name        string      `json:"name"`
+	Datasource  *Datasource `json:"datasource"`
+	Enable      bool        `json:"enable"`
+	Hide        bool        `json:"hide"`
+	IconColor   string      `json:"iconColor"`
+	BuiltIn     int         `json:"builtIn,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Expr        string      `json:"expr,omitempty"`
+	Step        string      `json:"step,omitempty"`
+	TitleFormat string      `json:"titleFormat,omitempty"`
+	TextFormat  string      `json:"textFormat,omitempty"`
+	TagKeys     string      `json:"tagKeys,omitempty"`
+}
+
+// Panel is a panel of a dashboard. Rows are also represented as panels.
+type Panel struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	GridPos     GridPos      `json:"gridPos"`
+	Datasource  *Datasource  `json:"datasource,omitempty"`
+	Targets     []*Target    `json:"targets,omitempty"`
+	FieldConfig *FieldConfig `json:"fieldConfig,omitempty"`
+	Options     any          `json:"options,omitempty"`
+	Collapsed   *bool        `json:"collapsed,omitempty"`
+	Panels      []*Panel     `json:"panels,omitempty"`
+}
+
+// GridPos is the position and the size of a panel in the 24-column grid.
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Target is a query of a panel.
+type Target struct {
+	RefID        string      `json:"refId"`
+	Datasource   *Datasource `json:"datasource"`
+	Expr         string      `json:"expr"`
+	LegendFormat string      `json:"legendFormat,omitempty"`
+}
+
+// FieldConfig configures how the values of a panel are displayed.
+type FieldConfig struct {
+	Defaults  FieldConfigDefaults `json:"defaults"`
+	Overrides []any               `json:"overrides"`
+}
+
+// FieldConfigDefaults is the default field config applied to all fields.
+type FieldConfigDefaults struct {
+	Unit       string         `json:"unit,omitempty"`
+	Min        *float64       `json:"min,omitempty"`
+	Max        *float64       `json:"max,omitempty"`
+	Decimals   *int           `json:"decimals,omitempty"`
+	Thresholds *Thresholds    `json:"thresholds,omitempty"`
+	Custom     map[string]any `json:"custom,omitempty"`
+}
+
+// Thresholds are the thresholds that color values.
+type Thresholds struct {
+	Mode  string           `json:"mode"`
+	Steps []*ThresholdStep `json:"steps"`
+}
+
+// ThresholdStep is a step of thresholds. A nil Value means the base step.
+type ThresholdStep struct {
+	Color string   `json:"color"`
+	Value *float64 `json:"value"`
+}
@@ -0,0 +1,228 @@
+package grafana
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/ajalab/slom/internal/prometheus/rule"
+	"github.com/ajalab/slom/internal/spec"
+)
+
+var reWindow = regexp.MustCompile(`\$window\b`)
+
+// SLO is an SLO in the Grafana SLO API.
+type SLO struct {
+	UUID                  string                    `json:"uuid"`
+	Name                  string                    `json:"name"`
+	Description           string                    `json:"description"`
+	Query                 SLOQuery                  `json:"query"`
+	Objectives            []*SLOObjective           `json:"objectives"`
+	Labels                []*SLOLabel               `json:"labels"`
+	Alerting              *SLOAlerting              `json:"alerting,omitempty"`
+	DestinationDatasource *SLODestinationDatasource `json:"destinationDatasource,omitempty"`
+}
+
+// SLOQuery is the query of an SLO.
+type SLOQuery struct {
+	Type     string           `json:"type"`
+	Freeform SLOFreeformQuery `json:"freeform"`
+}
+
+// SLOFreeformQuery is a PromQL query that returns the ratio of good events.
+type SLOFreeformQuery struct {
+	Query string `json:"query"`
+}
+
+// SLOObjective is the objective of an SLO over a window.
+type SLOObjective struct {
+	Value  float64 `json:"value"`
+	Window string  `json:"window"`
+}
+
+// SLOLabel is a label attached to an SLO or its alerts.
+type SLOLabel struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// SLOAlerting configures the burn rate alerts generated by Grafana SLO.
+type SLOAlerting struct {
+	FastBurn *SLOAlertingMetadata `json:"fastBurn,omitempty"`
+	SlowBurn *SLOAlertingMetadata `json:"slowBurn,omitempty"`
+}
+
+// SLOAlertingMetadata is the labels and annotations attached to a burn rate alert.
+type SLOAlertingMetadata struct {
+	Labels      []*SLOLabel `json:"labels"`
+	Annotations []*SLOLabel `json:"annotations"`
+}
+
+// SLODestinationDatasource is the data source where the recording rules of an SLO are written.
+type SLODestinationDatasource struct {
+	UID string `json:"uid"`
+}
+
+// SLOGeneratorOptions is a set of options for SLOGenerator.
+type SLOGeneratorOptions struct {
+	// DestinationDatasourceUID is the UID of the data source where Grafana writes recording rules.
+	DestinationDatasourceUID string
+}
+
+// SLOGenerator generates SLOs in the Grafana SLO API from specs.
+type SLOGenerator struct {
+	options *SLOGeneratorOptions
+}
+
+// NewSLOGenerator creates an SLOGenerator.
+func NewSLOGenerator(options *SLOGeneratorOptions) *SLOGenerator {
+	if options == nil {
+		options = &SLOGeneratorOptions{}
+	}
+	return &SLOGenerator{options: options}
+}
+
+// Generate generates an SLO for each SLO in s.
+func (g *SLOGenerator) Generate(s *spec.Spec) ([]*SLO, error) {
+	naming, err := rule.NewNaming(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var slos []*SLO
+	for _, slo := range s.SLOs() {
+		grafanaSLO, err := g.generateSLO(naming, s, slo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate a Grafana SLO for SLO %s: %w", slo.Name(), err)
+		}
+		slos = append(slos, grafanaSLO)
+	}
+	return slos, nil
+}
+
+func (g *SLOGenerator) generateSLO(naming *rule.Naming, s *spec.Spec, slo *spec.SLO) (*SLO, error) {
+	indicator, ok := slo.Indicator().(*spec.PrometheusIndicator)
+	if !ok {
+		return nil, fmt.Errorf("only prometheus indicator is supported")
+	}
+
+	if slo.Objective().RatioLabel() != "" {
+		return nil, fmt.Errorf("objectives by label values are not supported")
+	}
+
+	sloWindow, ok := slo.Objective().Window().(*spec.RollingWindow)
+	if !ok {
+		return nil, fmt.Errorf("the objective must have a rolling window")
+	}
+	window, err := days(sloWindow.Duration())
+	if err != nil {
+		return nil, err
+	}
+
+	alerting, err := sloAlerting(slo)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{}
+	maps.Copy(labels, s.Labels())
+	maps.Copy(labels, slo.Labels())
+	labels[naming.LabelNameSpec()] = s.Name()
+	labels[naming.LabelNameSLO()] = slo.Name()
+
+	var destination *SLODestinationDatasource
+	if g.options.DestinationDatasourceUID != "" {
+		destination = &SLODestinationDatasource{UID: g.options.DestinationDatasourceUID}
+	}
+
+	return &SLO{
+		UUID:        uid(naming.SLOId(s.Name(), slo.Name())),
+		Name:        s.Name() + " " + slo.Name(),
+		Description: slo.Annotations()["description"],
+		Query: SLOQuery{
+			Type: "freeform",
+			Freeform: SLOFreeformQuery{
+				Query: fmt.Sprintf("1 - (%s)", rateIntervalQuery(indicator.ErrorRatio())),
+			},
+		},
+		Objectives: []*SLOObjective{{
+			Value:  slo.Objective().Ratio(),
+			Window: window,
+		}},
+		Labels:                sloLabels(labels),
+		Alerting:              alerting,
+		DestinationDatasource: destination,
+	}, nil
+}
+
+func sloAlerting(slo *spec.SLO) (*SLOAlerting, error) {
+	var alerts []*spec.BurnRateAlert
+	for _, a := range slo.Alerts() {
+		switch a := a.(type) {
+		case *spec.BurnRateAlert:
+			if a.MinEvents() > 0 {
+				return nil, fmt.Errorf("minEvents is not supported")
+			}
+			alerts = append(alerts, a)
+		default:
+			return nil, fmt.Errorf("only burn rate alerts are supported")
+		}
+	}
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+	if len(alerts) > 2 {
+		return nil, fmt.Errorf("at most two burn rate alerts (fast burn and slow burn) are supported, but got %d", len(alerts))
+	}
+
+	sloWindow := slo.Objective().Window()
+	slices.SortStableFunc(alerts, func(a, b *spec.BurnRateAlert) int {
+		ta, tb := a.BurnRateThreshold(sloWindow), b.BurnRateThreshold(sloWindow)
+		switch {
+		case ta > tb:
+			return -1
+		case ta < tb:
+			return 1
+		}
+		return 0
+	})
+
+	alerting := &SLOAlerting{FastBurn: alertingMetadata(alerts[0])}
+	if len(alerts) > 1 {
+		alerting.SlowBurn = alertingMetadata(alerts[1])
+	}
+	return alerting, nil
+}
+
+func alertingMetadata(a *spec.BurnRateAlert) *SLOAlertingMetadata {
+	m := &SLOAlertingMetadata{Labels: []*SLOLabel{}, Annotations: []*SLOLabel{}}
+	if alerter, ok := a.Alerter().(*spec.PrometheusAlerter); ok {
+		m.Labels = sloLabels(alerter.Labels())
+		m.Annotations = sloLabels(alerter.Annotations())
+	}
+	return m
+}
+
+func sloLabels(labels map[string]string) []*SLOLabel {
+	ls := []*SLOLabel{}
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		ls = append(ls, &SLOLabel{Key: k, Value: labels[k]})
+	}
+	return ls
+}
+
+// rateIntervalQuery replaces the window in the error ratio query with $__rate_interval,
+// which Grafana SLO substitutes when it evaluates the query.
+func rateIntervalQuery(errorRatio string) string {
+	return reWindow.ReplaceAllLiteralString(errorRatio, "$__rate_interval")
+}
+
+func days(d spec.Duration) (string, error) {
+	const day = 24 * time.Hour
+	if time.Duration(d)%day != 0 {
+		return "", fmt.Errorf("window %s must be a multiple of a day", d)
+	}
+	return fmt.Sprintf("%dd", time.Duration(d)/day), nil
+}
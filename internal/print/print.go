@@ -3,8 +3,11 @@ package print
 import (
 	"encoding/json"
 	"fmt"
+	htmltemplate "html/template"
 	"io"
+	"io/fs"
 	"path"
+	"path/filepath"
 	"strings"
 	"text/template"
 
@@ -15,8 +18,12 @@ const (
 	outputJSON           = "json"
 	outputYAML           = "yaml"
 	outputGoTemplateFile = "go-template-file"
+	outputGoTemplateDir  = "go-template-dir"
 )
 
+// GoTemplateDirEntryPoint is the name of the template executed by the printer for the "go-template-dir" format.
+const GoTemplateDirEntryPoint = "index.tmpl"
+
 type Printer interface {
 	Print(v any) error
 	io.Closer
@@ -31,6 +38,9 @@ func NewPrinter(w io.Writer, output string) (Printer, error) {
 	case strings.HasPrefix(output, outputGoTemplateFile+"="):
 		goTemplateFileName := strings.TrimPrefix(output, outputGoTemplateFile+"=")
 		return NewGoTemplatePrinter(w, goTemplateFileName)
+	case strings.HasPrefix(output, outputGoTemplateDir+"="):
+		goTemplateDirName := strings.TrimPrefix(output, outputGoTemplateDir+"=")
+		return NewGoTemplateDirPrinter(w, goTemplateDirName)
 	}
 	return nil, fmt.Errorf("unsupported format: %s", output)
 }
@@ -84,13 +94,49 @@ type GoTemplatePrinter struct {
 }
 
 func NewGoTemplatePrinter(w io.Writer, goTemplateFileName string) (*GoTemplatePrinter, error) {
-	tmpl, err := template.New(path.Base(goTemplateFileName)).Funcs(funcMap).ParseFiles(goTemplateFileName)
+	tmpl, err := newGoTemplate(path.Base(goTemplateFileName)).ParseFiles(goTemplateFileName)
+	if err != nil {
+		return nil, err
+	}
+	return &GoTemplatePrinter{tmpl, w}, nil
+}
+
+// NewGoTemplateDirPrinter creates a printer that executes GoTemplateDirEntryPoint among the *.tmpl files in a directory.
+func NewGoTemplateDirPrinter(w io.Writer, goTemplateDirName string) (*GoTemplatePrinter, error) {
+	tmpl, err := newGoTemplate(GoTemplateDirEntryPoint).ParseGlob(filepath.Join(goTemplateDirName, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.Lookup(GoTemplateDirEntryPoint) == nil {
+		return nil, fmt.Errorf("%s is not found in %s", GoTemplateDirEntryPoint, goTemplateDirName)
+	}
+	return &GoTemplatePrinter{tmpl, w}, nil
+}
+
+// NewGoTemplatePrinterFS creates a printer with the template file named name in fsys.
+func NewGoTemplatePrinterFS(w io.Writer, fsys fs.FS, name string) (*GoTemplatePrinter, error) {
+	tmpl, err := newGoTemplate(path.Base(name)).ParseFS(fsys, name)
 	if err != nil {
 		return nil, err
 	}
 	return &GoTemplatePrinter{tmpl, w}, nil
 }
 
+// newGoTemplate creates a template with funcMap and the include function,
+// which executes a template in the same set and returns the result so that it can be piped (e.g., to indent).
+func newGoTemplate(name string) *template.Template {
+	tmpl := template.New(name)
+	return tmpl.Funcs(funcMap).Funcs(template.FuncMap{
+		"include": func(name string, data any) (string, error) {
+			var buf strings.Builder
+			if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+	})
+}
+
 var _ Printer = &GoTemplatePrinter{}
 
 func (p *GoTemplatePrinter) Print(v any) error {
@@ -100,3 +146,28 @@ func (p *GoTemplatePrinter) Print(v any) error {
 func (p *GoTemplatePrinter) Close() error {
 	return nil
 }
+
+// HTMLTemplatePrinter is a printer with an HTML template that escapes values safely.
+type HTMLTemplatePrinter struct {
+	tmpl *htmltemplate.Template
+	w    io.Writer
+}
+
+// NewHTMLTemplatePrinterFS creates a printer with the HTML template file named name and includes in fsys.
+func NewHTMLTemplatePrinterFS(w io.Writer, fsys fs.FS, name string, includes ...string) (*HTMLTemplatePrinter, error) {
+	tmpl, err := htmltemplate.New(path.Base(name)).Funcs(htmltemplate.FuncMap(funcMap)).ParseFS(fsys, append([]string{name}, includes...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &HTMLTemplatePrinter{tmpl, w}, nil
+}
+
+var _ Printer = &HTMLTemplatePrinter{}
+
+func (p *HTMLTemplatePrinter) Print(v any) error {
+	return p.tmpl.Execute(p.w, v)
+}
+
+func (p *HTMLTemplatePrinter) Close() error {
+	return nil
+}
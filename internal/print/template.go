@@ -2,13 +2,65 @@ package print
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"text/template"
+	"time"
 
+	"github.com/ajalab/slom/internal/spec"
+	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v3"
 )
 
 var funcMap = template.FuncMap{
+	// Serialization
 	"toYaml": toYAML,
+	"toJson": toJSON,
+
+	// Formatting
+	"percent":          percent,
+	"number":           number,
+	"duration":         duration,
+	"humanizeDuration": humanizeDuration,
+	"markdownEscape":   markdownEscape,
+	"markdownCode":     markdownCode,
+
+	// Strings
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trim":      strings.TrimSpace,
+	"replace":   replace,
+	"contains":  contains,
+	"hasPrefix": hasPrefix,
+	"hasSuffix": hasSuffix,
+	"split":     split,
+	"indent":    indent,
+	"nindent":   nindent,
+	"default":   defaultValue,
+
+	// Lists and maps
+	"list":       list,
+	"join":       join,
+	"joinLabels": joinLabels,
+	"keys":       keys,
+	"sortBy":     sortBy,
+
+	// Error budgets
+	"errorBudget":      errorBudget,
+	"burnRate":         burnRate,
+	"allowedBadTime":   allowedBadTime,
+	"timeToExhaustion": timeToExhaustion,
+}
+
+// FuncMap returns the functions available in templates.
+func FuncMap() template.FuncMap {
+	return maps.Clone(funcMap)
 }
 
 func toYAML(v interface{}) (string, error) {
@@ -21,3 +73,377 @@ func toYAML(v interface{}) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// percent formats a ratio as a percentage (e.g., 0.999 -> "99.9%").
+// If precision is given, the percentage is formatted with the number of decimal places.
+func percent(ratio float64, precision ...int) (string, error) {
+	p, err := optionalPrecision(precision)
+	if err != nil {
+		return "", err
+	}
+	return formatFloat(ratio*100, p) + "%", nil
+}
+
+// number formats a number. If precision is given, the number is formatted with the number of decimal places.
+func number(v any, precision ...int) (string, error) {
+	f, err := toFloat(v)
+	if err != nil {
+		return "", err
+	}
+	p, err := optionalPrecision(precision)
+	if err != nil {
+		return "", err
+	}
+	return formatFloat(f, p), nil
+}
+
+func optionalPrecision(precision []int) (int, error) {
+	switch len(precision) {
+	case 0:
+		return -1, nil
+	case 1:
+		return precision[0], nil
+	}
+	return 0, fmt.Errorf("too many arguments for precision")
+}
+
+func formatFloat(f float64, precision int) string {
+	if precision < 0 {
+		// Drop the error introduced by floating point arithmetic (e.g., 0.999 * 100 = 99.9000000000001).
+		f = math.Round(f*1e9) / 1e9
+	}
+	return strconv.FormatFloat(f, 'f', precision, 64)
+}
+
+// duration formats a duration in the Prometheus format (e.g., "4w", "1h30m").
+func duration(v any) (string, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+	return model.Duration(d).String(), nil
+}
+
+var humanizeDurationUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+	{"millisecond", time.Millisecond},
+}
+
+// humanizeDuration formats a duration in words (e.g., "4w" -> "28 days", "1h30m" -> "1 hour 30 minutes").
+func humanizeDuration(v any) (string, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for _, unit := range humanizeDurationUnits {
+		n := d / unit.d
+		if n == 0 {
+			continue
+		}
+		d -= n * unit.d
+
+		name := unit.name
+		if n != 1 {
+			name += "s"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", n, name))
+	}
+	if len(parts) == 0 {
+		return "0 seconds", nil
+	}
+	return strings.Join(parts, " "), nil
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`#`, `\#`,
+	`|`, `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+// markdownEscape escapes characters that have special meanings in Markdown so that s is rendered as is,
+// including in table cells. Line breaks are replaced with <br> so that they don't end table rows.
+func markdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// markdownCode returns s in a fenced code block whose fence is longer than any run of backticks in s.
+func markdownCode(s string) string {
+	fence := 3
+	run := 0
+	for _, c := range s {
+		if c != '`' {
+			run = 0
+			continue
+		}
+		run++
+		fence = max(fence, run+1)
+	}
+	f := strings.Repeat("`", fence)
+	return f + "\n" + s + "\n" + f
+}
+
+func replace(old, repl, s string) string {
+	return strings.ReplaceAll(s, old, repl)
+}
+
+func contains(substr, s string) bool {
+	return strings.Contains(s, substr)
+}
+
+func hasPrefix(prefix, s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+func hasSuffix(suffix, s string) bool {
+	return strings.HasSuffix(s, suffix)
+}
+
+func split(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// indent indents every line in s with n spaces.
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// nindent is the same as indent but prepends a new line, which is useful to put a multi-line value in YAML.
+func nindent(n int, s string) string {
+	return "\n" + indent(n, s)
+}
+
+// defaultValue returns v if it is not empty. Otherwise it returns def.
+func defaultValue(def any, v any) any {
+	if v == nil {
+		return def
+	}
+	if rv := reflect.ValueOf(v); rv.IsZero() || (isCollection(rv) && rv.Len() == 0) {
+		return def
+	}
+	return v
+}
+
+func list(items ...any) []any {
+	return items
+}
+
+// join concatenates the elements of a list with sep.
+func join(sep string, v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list but got %T", v)
+	}
+
+	items := make([]string, rv.Len())
+	for i := range rv.Len() {
+		items[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return strings.Join(items, sep), nil
+}
+
+// joinLabels formats labels as "name=value" pairs sorted by the names and joined with sep.
+func joinLabels(sep string, labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	return strings.Join(pairs, sep)
+}
+
+// keys returns the sorted keys of a map.
+func keys(v any) ([]string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return nil, fmt.Errorf("keys: expected a map but got %T", v)
+	}
+
+	ks := make([]string, 0, rv.Len())
+	for _, k := range rv.MapKeys() {
+		ks = append(ks, fmt.Sprint(k.Interface()))
+	}
+	slices.Sort(ks)
+	return ks, nil
+}
+
+// sortBy returns a copy of a list sorted by the field (or map key) named key of the elements.
+// Elements are compared as numbers if the values are numbers, otherwise as strings.
+func sortBy(key string, v any) ([]any, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("sortBy: expected a list but got %T", v)
+	}
+
+	items := make([]any, rv.Len())
+	values := make(map[int]reflect.Value, rv.Len())
+	for i := range rv.Len() {
+		item := rv.Index(i)
+		field, err := lookUp(item, key)
+		if err != nil {
+			return nil, fmt.Errorf("sortBy: %w", err)
+		}
+		items[i] = item.Interface()
+		values[i] = field
+	}
+
+	indices := make([]int, len(items))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortStableFunc(indices, func(i, j int) int {
+		return compareValues(values[i], values[j])
+	})
+
+	sorted := make([]any, len(items))
+	for i, index := range indices {
+		sorted[i] = items[index]
+	}
+	return sorted, nil
+}
+
+func lookUp(v reflect.Value, key string) (reflect.Value, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if f := v.FieldByName(key); f.IsValid() {
+			return f, nil
+		}
+	case reflect.Map:
+		if f := v.MapIndex(reflect.ValueOf(key)); f.IsValid() {
+			return f, nil
+		}
+		return reflect.ValueOf(""), nil
+	}
+	return reflect.Value{}, fmt.Errorf("%s has no field %s", v.Type(), key)
+}
+
+func compareValues(a, b reflect.Value) int {
+	fa, errA := toFloat(a.Interface())
+	fb, errB := toFloat(b.Interface())
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+}
+
+func isCollection(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return true
+	}
+	return false
+}
+
+// errorBudget returns the ratio of errors allowed by an objective ratio (e.g., 0.999 -> 0.001).
+func errorBudget(objectiveRatio float64) float64 {
+	return spec.RoundRatio(1 - objectiveRatio)
+}
+
+// burnRate returns the error budget burn rate at which consumedBudgetRatio of the error budget
+// for sloWindow is consumed in alertWindow.
+func burnRate(consumedBudgetRatio float64, sloWindow any, alertWindow any) (float64, error) {
+	s, err := toDuration(sloWindow)
+	if err != nil {
+		return 0, err
+	}
+	a, err := toDuration(alertWindow)
+	if err != nil {
+		return 0, err
+	}
+	if a == 0 {
+		return 0, fmt.Errorf("burnRate: alert window must not be zero")
+	}
+	return spec.RoundRatio(consumedBudgetRatio * float64(s) / float64(a)), nil
+}
+
+// allowedBadTime returns the duration of complete outage allowed by an objective ratio in window.
+func allowedBadTime(objectiveRatio float64, window any) (string, error) {
+	w, err := toDuration(window)
+	if err != nil {
+		return "", err
+	}
+	return spec.FormatDuration(float64(w) * errorBudget(objectiveRatio)), nil
+}
+
+// timeToExhaustion returns the time until the whole error budget for sloWindow is consumed at burnRate.
+func timeToExhaustion(burnRate float64, sloWindow any) (string, error) {
+	s, err := toDuration(sloWindow)
+	if err != nil {
+		return "", err
+	}
+	if burnRate <= 0 {
+		return "", fmt.Errorf("timeToExhaustion: burn rate must be positive")
+	}
+	return spec.FormatDuration(float64(s) / burnRate), nil
+}
+
+func toFloat(v any) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("cannot convert %T into a number", v)
+}
+
+func toDuration(v any) (time.Duration, error) {
+	switch v := v.(type) {
+	case time.Duration:
+		return v, nil
+	case model.Duration:
+		return time.Duration(v), nil
+	case string:
+		d, err := model.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse duration %s: %w", v, err)
+		}
+		return time.Duration(d), nil
+	}
+	return 0, fmt.Errorf("cannot convert %T into a duration", v)
+}
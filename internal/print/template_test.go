@@ -0,0 +1,55 @@
+package print
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFuncMap(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template string
+		data     any
+		expected string
+	}{
+		{"percent", `{{ percent 0.999 }}`, nil, "99.9%"},
+		{"percent-precision", `{{ percent 0.999 2 }}`, nil, "99.90%"},
+		{"number", `{{ number 13.44 1 }}`, nil, "13.4"},
+		{"duration", `{{ duration "168h" }}`, nil, "1w"},
+		{"humanizeDuration", `{{ humanizeDuration "4w" }}`, nil, "28 days"},
+		{"humanizeDuration-compound", `{{ humanizeDuration "1h30m1s" }}`, nil, "1 hour 30 minutes 1 second"},
+		{"markdownEscape", `{{ markdownEscape "a|b_c*" }}`, nil, `a\|b\_c\*`},
+		{"markdownEscape-newline", `{{ markdownEscape "a\nb" }}`, nil, `a<br>b`},
+		{"markdownCode", `{{ markdownCode "a" }}`, nil, "```\na\n```"},
+		{"markdownCode-backticks", "{{ markdownCode \"a ```` b\" }}", nil, "`````\na ```` b\n`````"},
+		{"indent", `{{ indent 2 "a\nb" }}`, nil, "  a\n  b"},
+		{"nindent", `{{ nindent 2 "a" }}`, nil, "\n  a"},
+		{"default", `{{ default "none" "" }},{{ default "none" "a" }}`, nil, "none,a"},
+		{"join", `{{ join ", " (list "a" 1 true) }}`, nil, "a, 1, true"},
+		{"joinLabels", `{{ joinLabels "," . }}`, map[string]string{"b": "2", "a": "1"}, "a=1,b=2"},
+		{"keys", `{{ keys . }}`, map[string]int{"b": 2, "a": 1}, "[a b]"},
+		{"sortBy", `{{ range sortBy "Name" . }}{{ .Name }}{{ end }}`, []struct{ Name string }{{"b"}, {"c"}, {"a"}}, "abc"},
+		{"sortBy-number", `{{ range sortBy "n" . }}{{ .n }}{{ end }}`, []map[string]any{{"n": 10}, {"n": 9}}, "910"},
+		{"toJson", `{{ toJson . }}`, map[string]int{"a": 1}, `{"a":1}`},
+		{"errorBudget", `{{ errorBudget 0.999 }}`, nil, "0.001"},
+		{"burnRate", `{{ burnRate 0.02 "4w" "1h" }}`, nil, "13.44"},
+		{"allowedBadTime", `{{ allowedBadTime 0.999 "4w" }}`, nil, "40m19s"},
+		{"timeToExhaustion", `{{ timeToExhaustion 13.44 "4w" }}`, nil, "2d2h"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := newGoTemplate(tc.name).Parse(tc.template)
+			if err != nil {
+				t.Fatalf("failed to parse the template: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, tc.data); err != nil {
+				t.Fatalf("failed to execute the template: %v", err)
+			}
+			if actual := buf.String(); actual != tc.expected {
+				t.Errorf("expected %q but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
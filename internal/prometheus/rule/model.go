@@ -36,6 +36,8 @@ func (rg RuleGroup) Prometheus() rulefmt.RuleGroup {
 }
 
 type Rule interface {
+	// Kind returns either "record" for recording rules or "alert" for alerting rules.
+	Kind() string
 	Prometheus() rulefmt.RuleNode
 }
 
@@ -43,10 +45,16 @@ type RecordingRule struct {
 	Record string            `json:"record" yaml:"record"`
 	Expr   string            `json:"expr" yaml:"expr"`
 	Labels map[string]string `json:"labels" yaml:"labels"`
+	// SharedBy are the IDs of the SLOs referring to the rule if it is shared by SLOs.
+	SharedBy []string `json:"sharedBy,omitempty" yaml:"sharedBy,omitempty"`
 }
 
 var _ Rule = &RecordingRule{}
 
+func (r *RecordingRule) Kind() string {
+	return "record"
+}
+
 func (r *RecordingRule) Prometheus() rulefmt.RuleNode {
 	return rulefmt.RuleNode{
 		Record: yaml.Node{
@@ -64,10 +72,17 @@ func (r *RecordingRule) Prometheus() rulefmt.RuleNode {
 type AlertingRule struct {
 	Alert       string            `json:"alert" yaml:"alert"`
 	Expr        string            `json:"expr" yaml:"expr"`
+	For         model.Duration    `json:"for,omitempty" yaml:"for,omitempty"`
 	Labels      map[string]string `json:"labels" yaml:"labels"`
 	Annotations map[string]string `json:"annotations" yaml:"annotations"`
 }
 
+var _ Rule = &AlertingRule{}
+
+func (r *AlertingRule) Kind() string {
+	return "alert"
+}
+
 func (r *AlertingRule) Prometheus() rulefmt.RuleNode {
 	return rulefmt.RuleNode{
 		Alert: yaml.Node{
@@ -78,6 +93,7 @@ func (r *AlertingRule) Prometheus() rulefmt.RuleNode {
 			Kind:  yaml.ScalarNode,
 			Value: r.Expr,
 		},
+		For:         r.For,
 		Labels:      r.Labels,
 		Annotations: r.Annotations,
 	}
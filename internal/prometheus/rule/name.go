@@ -1,38 +1,403 @@
 package rule
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
+	core "github.com/ajalab/slom/internal/config/spec/core/v1alpha"
 	"github.com/ajalab/slom/internal/spec"
+	"github.com/prometheus/common/model"
 )
 
-const labelNameSpec string = "slom_spec"
-const labelNameSLO string = "slom_slo"
-const labelNameId string = "slom_id"
+// Default names of the labels attached to the series recorded by slom.
+const (
+	DefaultLabelNameSpec      string = "slom_spec"
+	DefaultLabelNameSLO       string = "slom_slo"
+	DefaultLabelNameId        string = "slom_id"
+	DefaultLabelNameIndicator string = "slom_indicator"
+)
+
+// Default names of the labels attached to the metadata series recorded by slom.
+const (
+	DefaultLabelNameAlert       string = "slom_alert"
+	DefaultLabelNameAlertIndex  string = "slom_alert_index"
+	DefaultLabelNameWindow      string = "slom_window"
+	DefaultLabelNameSpecVersion string = "slom_spec_version"
+	DefaultLabelNameSpecHash    string = "slom_spec_hash"
+)
+
+// Default formats of the SLO ID and the names of the series recorded by slom.
+const (
+	DefaultSLOId                 string = "${spec}-${slo}"
+	DefaultRuleGroup             string = "slom:${id}:${kind}"
+	DefaultMetricNameErrorRate   string = "${level}slom_error:ratio_rate${window}"
+	DefaultMetricNameBurnRate    string = "${level}slom_burn_rate:ratio_rate${window}"
+	DefaultMetricNameSuccessRate string = "${level}slom_success:ratio_rate${window}"
+	DefaultMetricNameErrorBudget string = "${level}slom_error_budget:ratio_rate${window}"
+	DefaultMetricNameEvents      string = "${level}slom_events:increase${window}"
+	// The numerator and the denominator of error ratio queries are recorded to aggregate them over longer windows.
+	DefaultMetricNameErrorRatioNumerator   string = "${level}slom_error_ratio:numerator${window}"
+	DefaultMetricNameErrorRatioDenominator string = "${level}slom_error_ratio:denominator${window}"
+	DefaultMetricNameSLO                   string = "slom_slo"
+)
+
+// Default names of the metadata series recorded by slom.
+const (
+	DefaultMetricNameSLOWindow              string = "slom_slo_window_seconds"
+	DefaultMetricNameAlertBurnRateThreshold string = "slom_alert_burn_rate_threshold"
+	DefaultMetricNameAlertWindow            string = "slom_alert_window_seconds"
+	DefaultMetricNameSpecInfo               string = "slom_spec_info"
+)
+
+// Values of the window label of the metadata series for the windows of burn rate alerts.
+const (
+	// AlertWindowLong is the window of single window alerts and the long window of multiwindow alerts.
+	AlertWindowLong string = "long"
+	// AlertWindowShort is the short window of multiwindow alerts.
+	AlertWindowShort string = "short"
+)
+
+var rePlaceholder = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// Naming names the series and labels recorded by slom following the naming scheme of a spec.
+type Naming struct {
+	// config is the naming config of the spec with the default names filled in.
+	config core.NamingConfig
+}
+
+// NewNaming returns the validated naming of the series and labels for a spec.
+func NewNaming(s *spec.Spec) (*Naming, error) {
+	n := &Naming{}
+	if config := s.Naming(); config != nil {
+		n.config = *config
+	}
+
+	c := &n.config
+	for _, f := range []struct {
+		dst          *string
+		defaultValue string
+	}{
+		{&c.SLOId, DefaultSLOId},
+		{&c.RuleGroup, DefaultRuleGroup},
+		{&c.Labels.Spec, DefaultLabelNameSpec},
+		{&c.Labels.SLO, DefaultLabelNameSLO},
+		{&c.Labels.Id, DefaultLabelNameId},
+		{&c.Labels.Indicator, DefaultLabelNameIndicator},
+		{&c.Labels.Alert, DefaultLabelNameAlert},
+		{&c.Labels.AlertIndex, DefaultLabelNameAlertIndex},
+		{&c.Labels.Window, DefaultLabelNameWindow},
+		{&c.Labels.SpecVersion, DefaultLabelNameSpecVersion},
+		{&c.Labels.SpecHash, DefaultLabelNameSpecHash},
+		{&c.Metrics.ErrorRate, DefaultMetricNameErrorRate},
+		{&c.Metrics.BurnRate, DefaultMetricNameBurnRate},
+		{&c.Metrics.SuccessRate, DefaultMetricNameSuccessRate},
+		{&c.Metrics.ErrorBudget, DefaultMetricNameErrorBudget},
+		{&c.Metrics.Events, DefaultMetricNameEvents},
+		{&c.Metrics.ErrorRatioNumerator, DefaultMetricNameErrorRatioNumerator},
+		{&c.Metrics.ErrorRatioDenominator, DefaultMetricNameErrorRatioDenominator},
+		{&c.Metrics.SLO, DefaultMetricNameSLO},
+		{&c.Metrics.SLOWindow, DefaultMetricNameSLOWindow},
+		{&c.Metrics.AlertBurnRateThreshold, DefaultMetricNameAlertBurnRateThreshold},
+		{&c.Metrics.AlertWindow, DefaultMetricNameAlertWindow},
+		{&c.Metrics.SpecInfo, DefaultMetricNameSpecInfo},
+	} {
+		if *f.dst == "" {
+			*f.dst = f.defaultValue
+		}
+	}
+
+	if err := n.validate(s); err != nil {
+		return nil, fmt.Errorf("invalid naming: %w", err)
+	}
+	return n, nil
+}
+
+func (n *Naming) validate(s *spec.Spec) error {
+	labelNames := map[string]bool{}
+	for _, labelName := range []string{
+		n.config.Labels.Spec,
+		n.config.Labels.SLO,
+		n.config.Labels.Id,
+		n.config.Labels.Indicator,
+		n.config.Labels.Alert,
+		n.config.Labels.AlertIndex,
+		n.config.Labels.Window,
+		n.config.Labels.SpecVersion,
+		n.config.Labels.SpecHash,
+	} {
+		if !model.LabelName(labelName).IsValidLegacy() {
+			return fmt.Errorf("\"%s\" is not a valid label name", labelName)
+		}
+		if labelNames[labelName] {
+			return fmt.Errorf("label name \"%s\" is used more than once", labelName)
+		}
+		labelNames[labelName] = true
+	}
+
+	if err := validatePlaceholders(n.config.SLOId, "spec", "slo"); err != nil {
+		return fmt.Errorf("invalid SLO ID format \"%s\": %w", n.config.SLOId, err)
+	}
+	if err := validatePlaceholders(n.config.RuleGroup, "id", "kind"); err != nil {
+		return fmt.Errorf("invalid rule group name format \"%s\": %w", n.config.RuleGroup, err)
+	}
+	if !strings.Contains(n.config.RuleGroup, "${kind}") {
+		return fmt.Errorf("rule group name format \"%s\" must contain ${kind}", n.config.RuleGroup)
+	}
+	sloNamesById := map[string]string{}
+	for _, slo := range s.SLOs() {
+		id := n.SLOId(s.Name(), slo.Name())
+		if other, ok := sloNamesById[id]; ok {
+			return fmt.Errorf("SLOs \"%s\" and \"%s\" have the same ID \"%s\"", other, slo.Name(), id)
+		}
+		sloNamesById[id] = slo.Name()
+	}
+
+	metricNames := map[string]bool{}
+	for _, metricName := range []string{
+		n.config.Metrics.SLO,
+		n.config.Metrics.SLOWindow,
+		n.config.Metrics.AlertBurnRateThreshold,
+		n.config.Metrics.AlertWindow,
+		n.config.Metrics.SpecInfo,
+	} {
+		if !model.IsValidLegacyMetricName(metricName) {
+			return fmt.Errorf("\"%s\" is not a valid metric name", metricName)
+		}
+		if metricNames[metricName] {
+			return fmt.Errorf("metric name \"%s\" is used more than once", metricName)
+		}
+		metricNames[metricName] = true
+	}
+	// Each template is expanded with sample levels and a window to validate the resulting names.
+	for _, template := range []string{
+		n.config.Metrics.ErrorRate,
+		n.config.Metrics.BurnRate,
+		n.config.Metrics.SuccessRate,
+		n.config.Metrics.ErrorBudget,
+		n.config.Metrics.Events,
+		n.config.Metrics.ErrorRatioNumerator,
+		n.config.Metrics.ErrorRatioDenominator,
+	} {
+		if err := validatePlaceholders(template, "level", "window"); err != nil {
+			return fmt.Errorf("invalid metric name template \"%s\": %w", template, err)
+		}
+		if !strings.Contains(template, "${window}") {
+			return fmt.Errorf("metric name template \"%s\" must contain ${window}", template)
+		}
+		for _, levels := range [][]string{nil, {"job", "instance"}} {
+			metricName := expandMetricName(template, levels, spec.Duration(0))
+			if !model.IsValidLegacyMetricName(metricName) {
+				return fmt.Errorf("metric name template \"%s\" results in an invalid metric name \"%s\"", template, metricName)
+			}
+		}
+		metricName := expandMetricName(template, nil, spec.Duration(0))
+		if metricNames[metricName] {
+			return fmt.Errorf("metric name template \"%s\" collides with another one", template)
+		}
+		metricNames[metricName] = true
+	}
+	return nil
+}
+
+func validatePlaceholders(template string, names ...string) error {
+	for _, m := range rePlaceholder.FindAllStringSubmatch(template, -1) {
+		found := false
+		for _, name := range names {
+			if m[1] == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown placeholder %s", m[0])
+		}
+	}
+	return nil
+}
+
+func expandMetricName(template string, levels []string, duration spec.Duration) string {
+	var level string
+	if len(levels) > 0 {
+		level = strings.Join(levels, "_") + ":"
+	}
+	return strings.NewReplacer("${level}", level, "${window}", duration.String()).Replace(template)
+}
+
+// SLOId returns the value of the SLO ID label for an SLO.
+func (n *Naming) SLOId(specName string, sloName string) string {
+	return strings.NewReplacer("${spec}", specName, "${slo}", sloName).Replace(n.config.SLOId)
+}
+
+// RuleGroupName returns the name of a rule group for an SLO ID and a kind of rule groups.
+func (n *Naming) RuleGroupName(id string, kind string) string {
+	return strings.NewReplacer("${id}", id, "${kind}", kind).Replace(n.config.RuleGroup)
+}
+
+// LabelNameSpec returns the name of the label for the spec name.
+func (n *Naming) LabelNameSpec() string {
+	return n.config.Labels.Spec
+}
+
+// LabelNameSLO returns the name of the label for the SLO name.
+func (n *Naming) LabelNameSLO() string {
+	return n.config.Labels.SLO
+}
+
+// LabelNameId returns the name of the label for the SLO ID.
+func (n *Naming) LabelNameId() string {
+	return n.config.Labels.Id
+}
+
+// LabelNameIndicator returns the name of the label for the indicator of series shared by SLOs.
+func (n *Naming) LabelNameIndicator() string {
+	return n.config.Labels.Indicator
+}
+
+// LabelNameAlert returns the name of the label for the alert name in metadata series.
+func (n *Naming) LabelNameAlert() string {
+	return n.config.Labels.Alert
+}
 
-func sloId(specName string, sloName string) string {
-	return specName + "-" + sloName
+// LabelNameAlertIndex returns the name of the label for the index of alerts in an SLO in metadata series.
+func (n *Naming) LabelNameAlertIndex() string {
+	return n.config.Labels.AlertIndex
 }
 
-func metricNameErrorRate(
+// LabelNameWindow returns the name of the label for the kind of alert windows in metadata series.
+func (n *Naming) LabelNameWindow() string {
+	return n.config.Labels.Window
+}
+
+// LabelNameSpecVersion returns the name of the label for the spec version in metadata series.
+func (n *Naming) LabelNameSpecVersion() string {
+	return n.config.Labels.SpecVersion
+}
+
+// LabelNameSpecHash returns the name of the label for the spec hash in metadata series.
+func (n *Naming) LabelNameSpecHash() string {
+	return n.config.Labels.SpecHash
+}
+
+// MetricNameErrorRate returns the name of the error rate series recorded over a window.
+func (n *Naming) MetricNameErrorRate(
 	levels []string,
 	duration spec.Duration,
 ) string {
-	return metricNamePrefix(levels) + "slom_error:ratio_rate" + duration.String()
+	return expandMetricName(n.config.Metrics.ErrorRate, levels, duration)
 }
 
-func metricNameErrorBudget(
+// MetricNameBurnRate returns the name of the error budget burn rate series recorded over a window.
+func (n *Naming) MetricNameBurnRate(
 	levels []string,
 	duration spec.Duration,
 ) string {
-	return metricNamePrefix(levels) + "slom_error_budget:ratio_rate" + duration.String()
+	return expandMetricName(n.config.Metrics.BurnRate, levels, duration)
 }
 
-func metricNamePrefix(levels []string) string {
-	if len(levels) > 0 {
-		return strings.Join(levels, "_") + ":"
+// MetricNameSuccessRate returns the name of the success rate (1 - error rate) series recorded over a window.
+func (n *Naming) MetricNameSuccessRate(
+	levels []string,
+	duration spec.Duration,
+) string {
+	return expandMetricName(n.config.Metrics.SuccessRate, levels, duration)
+}
+
+// MetricNameErrorBudget returns the name of the remaining error budget series recorded over an SLO window.
+func (n *Naming) MetricNameErrorBudget(
+	levels []string,
+	duration spec.Duration,
+) string {
+	return expandMetricName(n.config.Metrics.ErrorBudget, levels, duration)
+}
+
+// MetricNameEvents returns the name of the series that records the number of events over a window.
+func (n *Naming) MetricNameEvents(
+	levels []string,
+	duration spec.Duration,
+) string {
+	return expandMetricName(n.config.Metrics.Events, levels, duration)
+}
+
+// MetricNameErrorRatioNumerator returns the name of the series that records the numerator of the error ratio over a window.
+func (n *Naming) MetricNameErrorRatioNumerator(
+	levels []string,
+	duration spec.Duration,
+) string {
+	return expandMetricName(n.config.Metrics.ErrorRatioNumerator, levels, duration)
+}
+
+// MetricNameErrorRatioDenominator returns the name of the series that records the denominator of the error ratio over a window.
+func (n *Naming) MetricNameErrorRatioDenominator(
+	levels []string,
+	duration spec.Duration,
+) string {
+	return expandMetricName(n.config.Metrics.ErrorRatioDenominator, levels, duration)
+}
+
+// MetricNameSLO returns the name of the series that records the objective ratio of an SLO.
+func (n *Naming) MetricNameSLO() string {
+	return n.config.Metrics.SLO
+}
+
+// MetricNameSLOWindow returns the name of the series that records the duration of the SLO window in seconds.
+func (n *Naming) MetricNameSLOWindow() string {
+	return n.config.Metrics.SLOWindow
+}
+
+// MetricNameAlertBurnRateThreshold returns the name of the series that records the threshold of a burn rate alert.
+func (n *Naming) MetricNameAlertBurnRateThreshold() string {
+	return n.config.Metrics.AlertBurnRateThreshold
+}
+
+// MetricNameAlertWindow returns the name of the series that records the duration of a window of a burn rate alert in seconds.
+func (n *Naming) MetricNameAlertWindow() string {
+	return n.config.Metrics.AlertWindow
+}
+
+// MetricNameSpecInfo returns the name of the series that records the version and the hash of the spec.
+func (n *Naming) MetricNameSpecInfo() string {
+	return n.config.Metrics.SpecInfo
+}
+
+// BurnRateExpr returns an expression of the error budget burn rate of an SLO from errorRate.
+func (n *Naming) BurnRateExpr(sloId string, objective *spec.Objective, errorRate string) string {
+	return n.objectiveExpr(sloId, objective, errorRate, "/", allowedErrorRatio)
+}
+
+// objectiveExpr returns an expression that applies a binary operator op to lhs and rhs, where rhs is
+// given an expression of the target ratio.
+// If the objective varies by label values, the series in lhs are joined with the metadata series of the target
+// ratios on the label, and the series with the label values without their own ratios fall back to the default ratio.
+func (n *Naming) objectiveExpr(
+	sloId string,
+	objective *spec.Objective,
+	lhs string,
+	op string,
+	rhs func(ratio string) string,
+) string {
+	ratio := fmt.Sprintf("%g", objective.Ratio())
+	label := objective.RatioLabel()
+	if label == "" {
+		return fmt.Sprintf("%s %s %s", lhs, op, rhs(ratio))
 	}
-	return ""
+
+	ratios := fmt.Sprintf("%s{%s=\"%s\", %s!=\"\"}", n.MetricNameSLO(), n.LabelNameId(), sloId, label)
+	return fmt.Sprintf(
+		"(%[1]s %[2]s on(%[3]s) group_left() %[4]s or (%[1]s unless on(%[3]s) %[5]s) %[2]s %[6]s)",
+		lhs, op, label, rhs(ratios), ratios, rhs(ratio),
+	)
+}
+
+func allowedErrorRatio(ratio string) string {
+	return fmt.Sprintf("(1 - %s)", ratio)
 }
 
-const metricNameSLO string = "slom_slo"
+// seriesSelector returns a selector of the series recorded by r.
+// Series shared by SLOs are selected by the indicator, and the others are selected by the SLO.
+func (n *Naming) seriesSelector(r *RecordingRule) string {
+	if indicatorId, ok := r.Labels[n.LabelNameIndicator()]; ok {
+		return fmt.Sprintf("%s{%s=\"%s\"}", r.Record, n.LabelNameIndicator(), indicatorId)
+	}
+	return fmt.Sprintf("%s{%s=\"%s\"}", r.Record, n.LabelNameId(), r.Labels[n.LabelNameId()])
+}
@@ -0,0 +1,116 @@
+package rule
+
+import (
+	"strings"
+	"testing"
+
+	configspec "github.com/ajalab/slom/internal/config/spec/native"
+	"github.com/ajalab/slom/internal/spec"
+)
+
+const namingTestSpec = `
+name: test
+naming:
+%s
+slos:
+  - name: availability
+    objective:
+      ratio: 0.99
+    indicator:
+      prometheus:
+        errorRatio: sum(rate(errors[$window])) / sum(rate(requests[$window]))
+    windows:
+      - name: window-4w
+        rolling:
+          duration: 4w
+  - name: latency
+    objective:
+      ratio: 0.99
+    indicator:
+      prometheus:
+        errorRatio: sum(rate(slow_requests[$window])) / sum(rate(requests[$window]))
+    windows:
+      - name: window-4w
+        rolling:
+          duration: 4w
+`
+
+func TestNewNamingValidation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		naming   string
+		expected string
+	}{
+		{
+			name:     "invalid-label-name",
+			naming:   "  labels:\n    id: slo-id",
+			expected: `"slo-id" is not a valid label name`,
+		},
+		{
+			name:     "duplicate-label-name",
+			naming:   "  labels:\n    id: slom_slo",
+			expected: `label name "slom_slo" is used more than once`,
+		},
+		{
+			name:     "invalid-metric-name",
+			naming:   "  metrics:\n    slo: slo-info",
+			expected: `"slo-info" is not a valid metric name`,
+		},
+		{
+			name:     "invalid-metric-name-template",
+			naming:   "  metrics:\n    errorRate: ${level}error-rate${window}",
+			expected: `metric name template "${level}error-rate${window}" results in an invalid metric name`,
+		},
+		{
+			name:     "unknown-placeholder-metric-name-template",
+			naming:   "  metrics:\n    errorRate: ${level}error_rate_${slo}${window}",
+			expected: `invalid metric name template "${level}error_rate_${slo}${window}": unknown placeholder ${slo}`,
+		},
+		{
+			name:     "unknown-placeholder-slo-id",
+			naming:   "  sloId: ${spec}-${window}",
+			expected: `invalid SLO ID format "${spec}-${window}": unknown placeholder ${window}`,
+		},
+		{
+			name:     "missing-kind",
+			naming:   "  ruleGroup: org:${id}",
+			expected: `rule group name format "org:${id}" must contain ${kind}`,
+		},
+		{
+			name:     "missing-window",
+			naming:   "  metrics:\n    errorRate: ${level}error_rate",
+			expected: `metric name template "${level}error_rate" must contain ${window}`,
+		},
+		{
+			name:     "colliding-metric-name-templates",
+			naming:   "  metrics:\n    errorRate: ${level}slom_burn_rate:ratio_rate${window}",
+			expected: `metric name template "${level}slom_burn_rate:ratio_rate${window}" collides with another one`,
+		},
+		{
+			name:     "duplicate-slo-id",
+			naming:   "  sloId: ${spec}",
+			expected: `SLOs "availability" and "latency" have the same ID "test"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			specConfig, err := configspec.ParseSpecConfig(strings.NewReader(strings.Replace(namingTestSpec, "%s", tc.naming, 1)))
+			if err != nil {
+				t.Fatalf("failed to parse the spec config: %v", err)
+			}
+			s, err := spec.ToSpec(specConfig)
+			if err != nil {
+				t.Fatalf("failed to convert the spec config: %v", err)
+			}
+
+			_, err = NewNaming(s)
+			if err == nil {
+				t.Fatalf("expected an error containing %q but got none", tc.expected)
+			}
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q but got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
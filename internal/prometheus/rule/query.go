@@ -1,9 +1,12 @@
 package rule
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/ajalab/slom/internal/spec"
+	"github.com/prometheus/prometheus/promql/parser"
 )
 
 var reWindow = regexp.MustCompile(`\$window\b`)
@@ -22,3 +25,58 @@ func generateErrorRateQuery(
 
 	return query
 }
+
+func generateEventsQuery(
+	indicator *spec.PrometheusIndicator,
+	window spec.Window,
+) string {
+	var query string
+	switch w := window.(type) {
+	case *spec.RollingWindow:
+		query = reWindow.ReplaceAllString(indicator.TotalEvents(), w.Duration().String())
+	case *spec.CalendarWindow:
+		query = ""
+	}
+
+	return query
+}
+
+// errorRatioQuery is an error ratio query split into its numerator and denominator.
+type errorRatioQuery struct {
+	numerator string
+	// operator is the division operator with its vector matching modifiers if any (e.g., "/ on(job)").
+	operator    string
+	denominator string
+}
+
+// splitErrorRatioQuery splits the error ratio query of indicator over window at its outermost division
+// so that its numerator and denominator can be summed up over longer windows.
+func splitErrorRatioQuery(
+	indicator *spec.PrometheusIndicator,
+	window spec.Window,
+) (*errorRatioQuery, error) {
+	query := generateErrorRateQuery(indicator, window)
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the error ratio query: %w", err)
+	}
+	for {
+		paren, ok := expr.(*parser.ParenExpr)
+		if !ok {
+			break
+		}
+		expr = paren.Expr
+	}
+
+	division, ok := expr.(*parser.BinaryExpr)
+	if !ok || division.Op != parser.DIV {
+		return nil, fmt.Errorf("the error ratio query must be a division of the number of errors by the number of events")
+	}
+	lhs := division.LHS.PositionRange()
+	rhs := division.RHS.PositionRange()
+	return &errorRatioQuery{
+		numerator:   query[lhs.Start:lhs.End],
+		operator:    strings.Join(strings.Fields(query[lhs.End:rhs.Start]), " "),
+		denominator: query[rhs.Start:rhs.End],
+	}, nil
+}
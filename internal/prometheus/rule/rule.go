@@ -1,8 +1,13 @@
 package rule
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/ajalab/slom/internal/spec"
 )
@@ -15,23 +20,56 @@ const (
 	ruleGroupMeta
 )
 
+// RuleGeneratorOptions is a set of options for RuleGenerator.
+type RuleGeneratorOptions struct {
+	// RecordBurnRate enables recording rules for the error budget burn rate over each window.
+	RecordBurnRate bool
+	// RecordSuccessRatio enables recording rules for the success ratio (1 - error ratio) over each window.
+	RecordSuccessRatio bool
+	// AggregateWindows derives the series over longer windows from those over the shortest rolling window.
+	AggregateWindows bool
+	// ShareRules records the error rates and the numbers of events once for SLOs with the same indicator.
+	ShareRules bool
+}
+
 type RuleGenerator struct {
+	options *RuleGeneratorOptions
+
+	// naming of the spec whose rules are being generated
+	naming *Naming
+
 	ruleGroups       []*RuleGroup
 	ruleGroupsByName map[string]*RuleGroup
 
 	// sloId → windowName → rule
 	errorRateRecordingRules map[string]map[string]*RecordingRule
 
+	// sloId → windowName → rule
+	burnRateRecordingRules map[string]map[string]*RecordingRule
+
+	// sloId → windowName → rule
+	eventsRecordingRules map[string]map[string]*RecordingRule
+
 	// sloId → rule
 	errorBudgetRecordingRules map[string]*RecordingRule
+
+	// record → labels → rule
+	sharedRecordingRules map[string]map[string]*RecordingRule
 }
 
-func NewRuleGenerator() *RuleGenerator {
+func NewRuleGenerator(options *RuleGeneratorOptions) *RuleGenerator {
+	if options == nil {
+		options = &RuleGeneratorOptions{}
+	}
 	return &RuleGenerator{
+		options:                   options,
 		ruleGroups:                nil,
 		ruleGroupsByName:          map[string]*RuleGroup{},
 		errorRateRecordingRules:   map[string]map[string]*RecordingRule{},
+		burnRateRecordingRules:    map[string]map[string]*RecordingRule{},
+		eventsRecordingRules:      map[string]map[string]*RecordingRule{},
 		errorBudgetRecordingRules: map[string]*RecordingRule{},
+		sharedRecordingRules:      map[string]map[string]*RecordingRule{},
 	}
 }
 
@@ -40,14 +78,15 @@ func (g *RuleGenerator) getOrCreateRuleGroup(
 	ruleGroupKind ruleGroupKind,
 	evaluationInterval spec.Duration,
 ) *RuleGroup {
-	var ruleGroupName string
+	var kind string
 	if ruleGroupKind == ruleGroupMeta {
-		ruleGroupName = fmt.Sprintf("slom:%s:meta", sloId)
+		kind = "meta"
 	} else if evaluationInterval == 0 {
-		ruleGroupName = fmt.Sprintf("slom:%s:default", sloId)
+		kind = "default"
 	} else {
-		ruleGroupName = fmt.Sprintf("slom:%s:%s", sloId, evaluationInterval.String())
+		kind = evaluationInterval.String()
 	}
+	ruleGroupName := g.naming.RuleGroupName(sloId, kind)
 
 	ruleGroup, ok := g.ruleGroupsByName[ruleGroupName]
 	if !ok {
@@ -65,8 +104,14 @@ func (g *RuleGenerator) getOrCreateRuleGroup(
 func (g *RuleGenerator) GenerateRecordingRules(
 	s *spec.Spec,
 ) error {
+	naming, err := NewNaming(s)
+	if err != nil {
+		return err
+	}
+	g.naming = naming
+
 	for _, slo := range s.SLOs() {
-		err := g.generateRecordingRules(s.Name(), slo)
+		err := g.generateRecordingRules(s, slo)
 		if err != nil {
 			return fmt.Errorf("failed to generate recording rules for SLO %s: %w", slo.Name(), err)
 		}
@@ -75,14 +120,15 @@ func (g *RuleGenerator) GenerateRecordingRules(
 }
 
 func (g *RuleGenerator) generateRecordingRules(
-	specName string,
+	s *spec.Spec,
 	slo *spec.SLO,
 ) error {
-	id := sloId(specName, slo.Name())
+	specName := s.Name()
+	id := g.naming.SLOId(specName, slo.Name())
 	labels := map[string]string{
-		labelNameSpec: specName,
-		labelNameSLO:  slo.Name(),
-		labelNameId:   id,
+		g.naming.LabelNameSpec(): specName,
+		g.naming.LabelNameSLO():  slo.Name(),
+		g.naming.LabelNameId():   id,
 	}
 
 	indicator, ok := slo.Indicator().(*spec.PrometheusIndicator)
@@ -90,9 +136,80 @@ func (g *RuleGenerator) generateRecordingRules(
 		return fmt.Errorf("only prometheus indicator is supported")
 	}
 
+	var baseWindow spec.Window
+	if g.options.AggregateWindows {
+		baseWindow = shortestRollingWindow(slo.Windows())
+		if err := validateBaseWindow(baseWindow, slo.Windows()); err != nil {
+			return fmt.Errorf("failed to aggregate windows: %w", err)
+		}
+	}
+
+	errorRatioWindow := recordedErrorRatioWindow(slo, g.options.AggregateWindows)
+	var errorRatio *errorRatioQuery
+	if g.options.AggregateWindows || hasCalendarWindow(slo.Windows()) {
+		if errorRatioWindow == nil {
+			return fmt.Errorf("calendar windows require a rolling window to record the error ratio")
+		}
+		var err error
+		errorRatio, err = splitErrorRatioQuery(indicator, errorRatioWindow)
+		if err != nil {
+			return fmt.Errorf("failed to record the error ratio over window \"%s\": %w", errorRatioWindow.Name(), err)
+		}
+	}
+
+	// Labels of the series that only depend on the indicator.
+	indicatorLabels := labels
+	if g.options.ShareRules {
+		indicatorLabels = map[string]string{
+			g.naming.LabelNameSpec():      specName,
+			g.naming.LabelNameIndicator(): indicatorId(indicator, errorRatioWindow),
+		}
+	}
+
 	for _, w := range slo.Windows() {
-		ruleErrorRate := g.generateErrorRateRecordingRule(indicator, w, labels)
+		ruleErrorRate := g.generateErrorRateRecordingRule(indicator, w, baseWindow, errorRatioWindow, errorRatio, indicatorLabels)
+		ruleErrorRate = g.shareRecordingRule(id, ruleErrorRate)
 		g.addErrorRateRecordingRule(id, w.Name(), ruleErrorRate, w.Prometheus().EvaluationInterval())
+		if errorRatioWindow != nil && w.Name() == errorRatioWindow.Name() {
+			for _, r := range g.generateErrorRatioRecordingRules(indicator, w, errorRatio, indicatorLabels) {
+				g.addRecordingRule(id, g.shareRecordingRule(id, r), w.Prometheus().EvaluationInterval())
+			}
+		}
+
+		errorRateQuery := g.naming.seriesSelector(ruleErrorRate)
+		if g.options.RecordSuccessRatio {
+			ruleSuccessRate := &RecordingRule{
+				Record: g.naming.MetricNameSuccessRate(indicator.Level(), w.Duration()),
+				Expr:   "1 - " + errorRateQuery,
+				Labels: labels,
+			}
+			g.addRecordingRule(id, ruleSuccessRate, w.Prometheus().EvaluationInterval())
+		}
+		if g.options.RecordBurnRate {
+			ruleBurnRate := &RecordingRule{
+				Record: g.naming.MetricNameBurnRate(indicator.Level(), w.Duration()),
+				Expr:   g.naming.BurnRateExpr(id, slo.Objective(), errorRateQuery),
+				Labels: labels,
+			}
+			g.addRecordingRule(id, ruleBurnRate, w.Prometheus().EvaluationInterval())
+
+			rules, ok := g.burnRateRecordingRules[id]
+			if !ok {
+				rules = make(map[string]*RecordingRule)
+				g.burnRateRecordingRules[id] = rules
+			}
+			rules[w.Name()] = ruleBurnRate
+		}
+
+		if indicator.TotalEvents() != "" {
+			ruleEvents := &RecordingRule{
+				Record: g.naming.MetricNameEvents(indicator.Level(), w.Duration()),
+				Expr:   g.generateEventsRecordingExpr(indicator, w, baseWindow, errorRatioWindow, indicatorLabels),
+				Labels: indicatorLabels,
+			}
+			ruleEvents = g.shareRecordingRule(id, ruleEvents)
+			g.addEventsRecordingRule(id, w.Name(), ruleEvents, w.Prometheus().EvaluationInterval())
+		}
 	}
 
 	sloWindow := slo.Objective().Window()
@@ -105,22 +222,141 @@ func (g *RuleGenerator) generateRecordingRules(
 	}
 
 	ruleMeta := &RecordingRule{
-		Record: metricNameSLO,
+		Record: g.naming.MetricNameSLO(),
 		Expr:   strconv.FormatFloat(slo.Objective().Ratio(), 'f', -1, 64),
 		Labels: labels,
 	}
 	g.addMetaRecordingRule(id, ruleMeta, spec.Duration(0))
+	if label := slo.Objective().RatioLabel(); label != "" {
+		ratios := slo.Objective().LabelRatios()
+		for _, value := range slices.Sorted(maps.Keys(ratios)) {
+			ratioLabels := maps.Clone(labels)
+			ratioLabels[label] = value
+			g.addMetaRecordingRule(id, &RecordingRule{
+				Record: g.naming.MetricNameSLO(),
+				Expr:   strconv.FormatFloat(ratios[value], 'f', -1, 64),
+				Labels: ratioLabels,
+			}, spec.Duration(0))
+		}
+	}
+	g.generateMetaRecordingRules(s, slo, id, labels)
 
 	return nil
 }
 
+// generateMetaRecordingRules generates recording rules that describe the SLO and its alerts
+// so that dashboards and other tools can discover them from Prometheus.
+func (g *RuleGenerator) generateMetaRecordingRules(
+	s *spec.Spec,
+	slo *spec.SLO,
+	sloId string,
+	labels map[string]string,
+) {
+	sloWindow := slo.Objective().Window()
+	if sloWindow != nil {
+		g.addMetaRecordingRule(sloId, &RecordingRule{
+			Record: g.naming.MetricNameSLOWindow(),
+			Expr:   seconds(sloWindow.Duration()),
+			Labels: labels,
+		}, spec.Duration(0))
+
+		for i, a := range slo.Alerts() {
+			a, ok := a.(*spec.BurnRateAlert)
+			if !ok {
+				continue
+			}
+			alerter, ok := a.Alerter().(*spec.PrometheusAlerter)
+			if !ok {
+				continue
+			}
+
+			alertLabels := maps.Clone(alerter.Labels())
+			if alertLabels == nil {
+				alertLabels = map[string]string{}
+			}
+			maps.Copy(alertLabels, labels)
+			alertLabels[g.naming.LabelNameAlert()] = alerter.Name()
+			alertLabels[g.naming.LabelNameAlertIndex()] = strconv.Itoa(i)
+
+			g.addMetaRecordingRule(sloId, &RecordingRule{
+				Record: g.naming.MetricNameAlertBurnRateThreshold(),
+				Expr:   strconv.FormatFloat(a.BurnRateThreshold(sloWindow), 'f', -1, 64),
+				Labels: alertLabels,
+			}, spec.Duration(0))
+
+			windows := map[string]spec.Window{}
+			switch w := a.Window().(type) {
+			case *spec.BurnRateAlertSingleWindow:
+				windows[AlertWindowLong] = w.Window()
+			case *spec.BurnRateAlertMultiWindows:
+				windows[AlertWindowLong] = w.LongWindow()
+				windows[AlertWindowShort] = w.ShortWindow()
+			}
+			for _, kind := range []string{AlertWindowLong, AlertWindowShort} {
+				w, ok := windows[kind]
+				if !ok {
+					continue
+				}
+				windowLabels := maps.Clone(alertLabels)
+				windowLabels[g.naming.LabelNameWindow()] = kind
+				g.addMetaRecordingRule(sloId, &RecordingRule{
+					Record: g.naming.MetricNameAlertWindow(),
+					Expr:   seconds(w.Duration()),
+					Labels: windowLabels,
+				}, spec.Duration(0))
+			}
+		}
+	}
+
+	infoLabels := maps.Clone(labels)
+	if s.Version() != "" {
+		infoLabels[g.naming.LabelNameSpecVersion()] = s.Version()
+	}
+	infoLabels[g.naming.LabelNameSpecHash()] = s.Hash()
+	g.addMetaRecordingRule(sloId, &RecordingRule{
+		Record: g.naming.MetricNameSpecInfo(),
+		Expr:   "1",
+		Labels: infoLabels,
+	}, spec.Duration(0))
+}
+
+func seconds(d spec.Duration) string {
+	return strconv.FormatInt(int64(time.Duration(d)/time.Second), 10)
+}
+
+// generateErrorRateRecordingRule generates the error rate recording rule over window.
+// For aggregated windows and calendar windows, the error rate is the sum of the numerators of the error ratio
+// over errorRatioWindow divided by the sum of the denominators.
 func (g *RuleGenerator) generateErrorRateRecordingRule(
 	indicator *spec.PrometheusIndicator,
 	window spec.Window,
+	baseWindow spec.Window,
+	errorRatioWindow spec.Window,
+	errorRatio *errorRatioQuery,
 	labels map[string]string,
 ) *RecordingRule {
-	name := metricNameErrorRate(indicator.Level(), window.Duration())
-	expr := generateErrorRateQuery(indicator, window)
+	name := g.naming.MetricNameErrorRate(indicator.Level(), window.Duration())
+	var expr string
+	if w, ok := window.(*spec.CalendarWindow); ok {
+		numerator, denominator := g.errorRatioSelectors(indicator, errorRatioWindow, labels)
+		expr = fmt.Sprintf(
+			"(%s) %s (%s)",
+			calendarSumExpr(numerator, w, errorRatioWindow.Duration()),
+			errorRatio.operator,
+			calendarSumExpr(denominator, w, errorRatioWindow.Duration()),
+		)
+	} else if isAggregatedWindow(window, baseWindow) {
+		numerator, denominator := g.errorRatioSelectors(indicator, errorRatioWindow, labels)
+		expr = fmt.Sprintf(
+			"sum_over_time(%[1]s[%[4]s]) %[2]s sum_over_time(%[3]s[%[4]s])",
+			numerator,
+			errorRatio.operator,
+			denominator,
+			window.Duration().String(),
+		)
+	} else {
+		expr = generateErrorRateQuery(indicator, window)
+	}
 
 	return &RecordingRule{
 		Record: name,
@@ -129,14 +365,53 @@ func (g *RuleGenerator) generateErrorRateRecordingRule(
 	}
 }
 
+// generateErrorRatioRecordingRules generates the recording rules of the numerator and the denominator
+// of the error ratio over the base window.
+func (g *RuleGenerator) generateErrorRatioRecordingRules(
+	indicator *spec.PrometheusIndicator,
+	baseWindow spec.Window,
+	errorRatio *errorRatioQuery,
+	labels map[string]string,
+) []*RecordingRule {
+	return []*RecordingRule{
+		{
+			Record: g.naming.MetricNameErrorRatioNumerator(indicator.Level(), baseWindow.Duration()),
+			Expr:   errorRatio.numerator,
+			Labels: labels,
+		},
+		{
+			Record: g.naming.MetricNameErrorRatioDenominator(indicator.Level(), baseWindow.Duration()),
+			Expr:   errorRatio.denominator,
+			Labels: labels,
+		},
+	}
+}
+
+// errorRatioSelectors returns the selectors of the series of the numerator and the denominator of the error ratio
+// recorded over errorRatioWindow.
+func (g *RuleGenerator) errorRatioSelectors(
+	indicator *spec.PrometheusIndicator,
+	errorRatioWindow spec.Window,
+	labels map[string]string,
+) (string, string) {
+	numerator := &RecordingRule{
+		Record: g.naming.MetricNameErrorRatioNumerator(indicator.Level(), errorRatioWindow.Duration()),
+		Labels: labels,
+	}
+	denominator := &RecordingRule{
+		Record: g.naming.MetricNameErrorRatioDenominator(indicator.Level(), errorRatioWindow.Duration()),
+		Labels: labels,
+	}
+	return g.naming.seriesSelector(numerator), g.naming.seriesSelector(denominator)
+}
+
 func (g *RuleGenerator) addErrorRateRecordingRule(
 	sloId string,
 	windowName string,
 	r *RecordingRule,
 	evaluationInterval spec.Duration,
 ) {
-	ruleGroup := g.getOrCreateRuleGroup(sloId, ruleGroupRecord, evaluationInterval)
-	ruleGroup.Rules = append(ruleGroup.Rules, r)
+	g.addRecordingRule(sloId, r, evaluationInterval)
 
 	rules, ok := g.errorRateRecordingRules[sloId]
 	if !ok {
@@ -162,6 +437,202 @@ func (g *RuleGenerator) getErrorRateRecordingRule(
 	return rule, nil
 }
 
+func (g *RuleGenerator) addRecordingRule(
+	sloId string,
+	r *RecordingRule,
+	evaluationInterval spec.Duration,
+) {
+	if len(r.SharedBy) > 1 {
+		// Shared rules are added to the rule group of the SLO that refers to them first.
+		return
+	}
+	ruleGroup := g.getOrCreateRuleGroup(sloId, ruleGroupRecord, evaluationInterval)
+	ruleGroup.Rules = append(ruleGroup.Rules, r)
+}
+
+func (g *RuleGenerator) addEventsRecordingRule(
+	sloId string,
+	windowName string,
+	r *RecordingRule,
+	evaluationInterval spec.Duration,
+) {
+	g.addRecordingRule(sloId, r, evaluationInterval)
+
+	rules, ok := g.eventsRecordingRules[sloId]
+	if !ok {
+		rules = make(map[string]*RecordingRule)
+		g.eventsRecordingRules[sloId] = rules
+	}
+
+	rules[windowName] = r
+}
+
+// generateEventsRecordingExpr returns the expression of the events recording rule over window.
+// For aggregated windows, the average number of events over the base window is scaled up to the window.
+// For calendar windows, the numbers of events over errorRatioWindow are summed up over the current period.
+func (g *RuleGenerator) generateEventsRecordingExpr(
+	indicator *spec.PrometheusIndicator,
+	window spec.Window,
+	baseWindow spec.Window,
+	errorRatioWindow spec.Window,
+	labels map[string]string,
+) string {
+	if w, ok := window.(*spec.CalendarWindow); ok {
+		events := &RecordingRule{
+			Record: g.naming.MetricNameEvents(indicator.Level(), errorRatioWindow.Duration()),
+			Labels: labels,
+		}
+		return calendarSumExpr(g.naming.seriesSelector(events), w, errorRatioWindow.Duration())
+	}
+	if !isAggregatedWindow(window, baseWindow) {
+		return generateEventsQuery(indicator, window)
+	}
+	base := &RecordingRule{
+		Record: g.naming.MetricNameEvents(indicator.Level(), baseWindow.Duration()),
+		Labels: labels,
+	}
+	return fmt.Sprintf(
+		"avg_over_time(%s[%s]) * %d",
+		g.naming.seriesSelector(base),
+		window.Duration().String(),
+		window.Duration()/baseWindow.Duration(),
+	)
+}
+
+// recordedErrorRatioWindow returns the window over which the numerator and the denominator of the error ratio are recorded
+// to derive the error rates over the aggregated windows and the calendar windows, or nil if they are not recorded.
+// It is the shortest rolling window of the SLO.
+func recordedErrorRatioWindow(slo *spec.SLO, aggregateWindows bool) spec.Window {
+	if !aggregateWindows && !hasCalendarWindow(slo.Windows()) {
+		return nil
+	}
+	return shortestRollingWindow(slo.Windows())
+}
+
+// calendarSumExpr returns an expression that sums up the series selected by selector over the current period
+// of a calendar window, sampling them at every step.
+// Since the range of a subquery cannot start at the beginning of the period, the subquery covers the whole duration
+// of the window, which spans the current period p and the previous one. Each sample is weighted with its period number,
+// and subtracting the sum weighted with p - 1 cancels out the samples of the previous period.
+func calendarSumExpr(selector string, window *spec.CalendarWindow, step spec.Duration) string {
+	period := fmt.Sprintf("floor((time() - %d) / %s)", window.Start().Unix(), seconds(window.Duration()))
+	subquery := fmt.Sprintf("[%s:%s]", window.Duration().String(), step.String())
+	return fmt.Sprintf(
+		"sum_over_time((%[1]s * %[2]s)%[3]s) - (%[2]s - 1) * sum_over_time(%[1]s%[3]s)",
+		selector, period, subquery,
+	)
+}
+
+func hasCalendarWindow(windows []spec.Window) bool {
+	for _, w := range windows {
+		if _, ok := w.(*spec.CalendarWindow); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isAggregatedWindow reports whether the series over window are derived from those over baseWindow.
+func isAggregatedWindow(window spec.Window, baseWindow spec.Window) bool {
+	if baseWindow == nil || window.Name() == baseWindow.Name() {
+		return false
+	}
+	_, ok := window.(*spec.RollingWindow)
+	return ok
+}
+
+// validateBaseWindow checks that the durations of all rolling windows are multiples of that of baseWindow.
+func validateBaseWindow(baseWindow spec.Window, windows []spec.Window) error {
+	if baseWindow == nil {
+		return fmt.Errorf("no rolling window is defined")
+	}
+	for _, w := range windows {
+		if !isAggregatedWindow(w, baseWindow) {
+			continue
+		}
+		if w.Duration()%baseWindow.Duration() != 0 {
+			return fmt.Errorf(
+				"duration %s of window \"%s\" is not a multiple of duration %s of base window \"%s\"",
+				w.Duration().String(), w.Name(), baseWindow.Duration().String(), baseWindow.Name(),
+			)
+		}
+	}
+	return nil
+}
+
+// guardExpr appends a condition to expr that requires at least minEvents events over the window
+// so that alerts do not fire on a few failed events of low-traffic services.
+func (g *RuleGenerator) guardExpr(
+	sloId string,
+	expr string,
+	window spec.Window,
+	minEvents int,
+) (string, error) {
+	if minEvents == 0 {
+		return expr, nil
+	}
+
+	rule, ok := g.eventsRecordingRules[sloId][window.Name()]
+	if !ok {
+		return "", fmt.Errorf("could not find an events recording rule with windowName %s for SLO %s", window.Name(), sloId)
+	}
+	if _, ok := rule.Labels[g.naming.LabelNameIndicator()]; ok {
+		// Shared series do not have the labels identifying the SLO.
+		return fmt.Sprintf("%s and ignoring(%s, %s) %s >= %d", expr, g.naming.LabelNameId(), g.naming.LabelNameSLO(), g.naming.seriesSelector(rule), minEvents), nil
+	}
+	return fmt.Sprintf("%s and %s >= %d", expr, g.naming.seriesSelector(rule), minEvents), nil
+}
+
+// shareRecordingRule returns the recording rule recorded with the same name and labels as r if any.
+// Otherwise it returns r, which is going to be added by the caller.
+// Only rules labeled with an indicator are shared, and they remember the SLOs referring to them.
+func (g *RuleGenerator) shareRecordingRule(
+	sloId string,
+	r *RecordingRule,
+) *RecordingRule {
+	key, ok := r.Labels[g.naming.LabelNameIndicator()]
+	if !ok {
+		return r
+	}
+
+	rules, ok := g.sharedRecordingRules[r.Record]
+	if !ok {
+		rules = make(map[string]*RecordingRule)
+		g.sharedRecordingRules[r.Record] = rules
+	}
+	key = r.Labels[g.naming.LabelNameSpec()] + "/" + key
+	if shared, ok := rules[key]; ok {
+		shared.SharedBy = append(shared.SharedBy, sloId)
+		return shared
+	}
+	r.SharedBy = []string{sloId}
+	rules[key] = r
+	return r
+}
+
+// IndicatorId returns the value of the indicator label of the series shared by SLOs with the same indicator.
+func IndicatorId(slo *spec.SLO, options *RuleGeneratorOptions) (string, error) {
+	indicator, ok := slo.Indicator().(*spec.PrometheusIndicator)
+	if !ok {
+		return "", fmt.Errorf("only prometheus indicator is supported")
+	}
+	if options == nil {
+		options = &RuleGeneratorOptions{}
+	}
+	return indicatorId(indicator, recordedErrorRatioWindow(slo, options.AggregateWindows)), nil
+}
+
+// indicatorId returns an identifier of the series computed from indicator.
+// baseWindow is taken into account since it changes the expressions of the aggregated windows and the calendar windows.
+func indicatorId(indicator *spec.PrometheusIndicator, baseWindow spec.Window) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%q\n%q\n%q\n", indicator.ErrorRatio(), indicator.Level(), indicator.TotalEvents())
+	if baseWindow != nil {
+		fmt.Fprintf(h, "%s\n", baseWindow.Duration().String())
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
 func (g *RuleGenerator) generateErrorBudgetRecordingRule(
 	indicator *spec.PrometheusIndicator,
 	sloId string,
@@ -169,29 +640,16 @@ func (g *RuleGenerator) generateErrorBudgetRecordingRule(
 	labels map[string]string,
 ) (*RecordingRule, error) {
 	sloWindow := objective.Window()
-	name := metricNameErrorBudget(indicator.Level(), sloWindow.Duration())
+	name := g.naming.MetricNameErrorBudget(indicator.Level(), sloWindow.Duration())
 
 	errorRateRule, err := g.getErrorRateRecordingRule(sloId, sloWindow.Name())
 	if err != nil {
 		return nil, fmt.Errorf("could not find an error rate recording rule for error budget recording rule: %w", err)
 	}
 
-	var expr string
-	switch sloWindow.(type) {
-	case *spec.RollingWindow:
-		expr = fmt.Sprintf(
-			"1 - %s{%s=\"%s\"} / (1 - %g)",
-			errorRateRule.Record,
-			labelNameId,
-			sloId,
-			// prometheus.GenerateLabels(labels, true),
-			objective.Ratio(),
-		)
-
-	}
 	return &RecordingRule{
 		Record: name,
-		Expr:   expr,
+		Expr:   "1 - " + g.naming.BurnRateExpr(sloId, objective, g.naming.seriesSelector(errorRateRule)),
 		Labels: labels,
 	}, nil
 }
@@ -229,6 +687,12 @@ func (g *RuleGenerator) addMetaRecordingRule(
 func (g *RuleGenerator) GenerateAlertingRules(
 	s *spec.Spec,
 ) error {
+	naming, err := NewNaming(s)
+	if err != nil {
+		return err
+	}
+	g.naming = naming
+
 	for _, slo := range s.SLOs() {
 		err := g.generateAlertingRules(s.Name(), slo)
 		if err != nil {
@@ -246,7 +710,7 @@ func (g *RuleGenerator) generateAlertingRules(
 		return nil
 	}
 
-	id := sloId(specName, slo.Name())
+	id := g.naming.SLOId(specName, slo.Name())
 
 	for _, a := range slo.Alerts() {
 		var rule *AlertingRule
@@ -257,13 +721,32 @@ func (g *RuleGenerator) generateAlertingRules(
 			rule, err = g.generateBurnRateAlertingRule(id, slo.Objective(), a)
 			window = a.Window().Window()
 		case *spec.ErrorBudgetAlert:
-			rule, err = g.generateErrorBudgetAlertingRule(id, a)
+			rule, err = g.generateErrorBudgetAlertingRule(id, slo.Objective(), a)
+			window = slo.Objective().Window()
+		case *spec.ErrorBudgetForecastAlert:
+			rule, err = g.generateErrorBudgetForecastAlertingRule(id, slo.Objective(), a)
 			window = slo.Objective().Window()
+		case *spec.SLIAbsentAlert:
+			window = shortestRollingWindow(slo.Windows())
+			if window == nil {
+				return fmt.Errorf("SLI absence alert requires a rolling window")
+			}
+			rule, err = g.generateSLIAbsentAlertingRule(specName, slo.Name(), window, a)
 		}
 
 		if err != nil {
 			return fmt.Errorf("failed to generate alerting rule for alert %s: %w", a.Name(), err)
 		}
+		if g.options.ShareRules {
+			// Alerts on shared series do not inherit the labels identifying the SLO.
+			rule.Labels = maps.Clone(rule.Labels)
+			if rule.Labels == nil {
+				rule.Labels = map[string]string{}
+			}
+			rule.Labels[g.naming.LabelNameSpec()] = specName
+			rule.Labels[g.naming.LabelNameSLO()] = slo.Name()
+			rule.Labels[g.naming.LabelNameId()] = id
+		}
 		g.addAlertingRule(id, rule, window.Prometheus().EvaluationInterval())
 	}
 
@@ -285,36 +768,35 @@ func (g *RuleGenerator) generateBurnRateAlertingRule(
 		return nil, fmt.Errorf("SLO window is not defined")
 	}
 
-	burnRateThreshold := a.ConsumedBudgetRatio() * float64(sloWindow.Duration()) / float64(a.Window().Window().Duration())
-	errorRateThreshold := fmt.Sprintf("%g * %g", burnRateThreshold, 1-objective.Ratio())
+	burnRateThreshold := a.BurnRateThreshold(sloWindow)
 
 	var expr string
+	var guardWindow spec.Window
 	switch w := a.Window().(type) {
 	case *spec.BurnRateAlertSingleWindow:
-		windowName := w.Window().Name()
-		errorRateRule, err := g.getErrorRateRecordingRule(sloId, windowName)
+		guardWindow = w.Window()
+		condition, err := g.burnRateCondition(sloId, w.Window(), objective, burnRateThreshold)
 		if err != nil {
-			return nil, fmt.Errorf("could not find an error rate recording rule for the window of the burn rate alert rule: %w", err)
+			return nil, fmt.Errorf("could not find a recording rule for the window of the burn rate alert rule: %w", err)
 		}
-
-		errorRateQuery := fmt.Sprintf("%s{%s=\"%s\"}", errorRateRule.Record, labelNameId, sloId)
-		expr = fmt.Sprintf("%s > %s", errorRateQuery, errorRateThreshold)
+		expr = condition
 
 	case *spec.BurnRateAlertMultiWindows:
-		shortWindowName := w.ShortWindow().Name()
-		longWindowName := w.LongWindow().Name()
-		errorRateRuleShort, err := g.getErrorRateRecordingRule(sloId, shortWindowName)
+		conditionShort, err := g.burnRateCondition(sloId, w.ShortWindow(), objective, burnRateThreshold)
 		if err != nil {
-			return nil, fmt.Errorf("could not find an error rate recording rule for the short window of the burn rate alert rule: %w", err)
+			return nil, fmt.Errorf("could not find a recording rule for the short window of the burn rate alert rule: %w", err)
 		}
-		errorRateRuleLong, err := g.getErrorRateRecordingRule(sloId, longWindowName)
+		conditionLong, err := g.burnRateCondition(sloId, w.LongWindow(), objective, burnRateThreshold)
 		if err != nil {
-			return nil, fmt.Errorf("could not find an error rate recording rule for the long window of the burn rate alert rule: %w", err)
+			return nil, fmt.Errorf("could not find a recording rule for the long window of the burn rate alert rule: %w", err)
 		}
+		expr = fmt.Sprintf("%s and %s", conditionLong, conditionShort)
+		guardWindow = w.LongWindow()
+	}
 
-		errorRateQueryShort := fmt.Sprintf("%s{%s=\"%s\"}", errorRateRuleShort.Record, labelNameId, sloId)
-		errorRateQueryLong := fmt.Sprintf("%s{%s=\"%s\"}", errorRateRuleLong.Record, labelNameId, sloId)
-		expr = fmt.Sprintf("%[1]s > %[3]s and %[2]s > %[3]s", errorRateQueryLong, errorRateQueryShort, errorRateThreshold)
+	expr, err := g.guardExpr(sloId, expr, guardWindow, a.MinEvents())
+	if err != nil {
+		return nil, err
 	}
 
 	return &AlertingRule{
@@ -325,8 +807,37 @@ func (g *RuleGenerator) generateBurnRateAlertingRule(
 	}, nil
 }
 
+// burnRateCondition returns a condition that holds when the burn rate over window exceeds threshold.
+// It refers to the recorded burn rate if enabled, otherwise compares the recorded error rate with the corresponding error rate.
+func (g *RuleGenerator) burnRateCondition(
+	sloId string,
+	window spec.Window,
+	objective *spec.Objective,
+	threshold float64,
+) (string, error) {
+	if g.options.RecordBurnRate {
+		rule, ok := g.burnRateRecordingRules[sloId][window.Name()]
+		if !ok {
+			return "", fmt.Errorf("burn rate recording rule with windowName %s for SLO %s was not generated", window.Name(), sloId)
+		}
+		return fmt.Sprintf("%s > %g", g.naming.seriesSelector(rule), threshold), nil
+	}
+
+	rule, err := g.getErrorRateRecordingRule(sloId, window.Name())
+	if err != nil {
+		return "", err
+	}
+	if objective.RatioLabel() != "" {
+		return g.naming.objectiveExpr(sloId, objective, g.naming.seriesSelector(rule), ">", func(ratio string) string {
+			return fmt.Sprintf("%g * %s", threshold, allowedErrorRatio(ratio))
+		}), nil
+	}
+	return fmt.Sprintf("%s > %g * %g", g.naming.seriesSelector(rule), threshold, 1-objective.Ratio()), nil
+}
+
 func (g *RuleGenerator) generateErrorBudgetAlertingRule(
 	sloId string,
+	objective *spec.Objective,
 	a *spec.ErrorBudgetAlert,
 ) (*AlertingRule, error) {
 	alerter, ok := a.Alerter().(*spec.PrometheusAlerter)
@@ -338,7 +849,59 @@ func (g *RuleGenerator) generateErrorBudgetAlertingRule(
 	if err != nil {
 		return nil, fmt.Errorf("could not find an error budget recording rule for the error budget alert rule: %w", err)
 	}
-	expr := fmt.Sprintf("%s{%s=\"%s\"} <= 1 - %g", errorBudgetRule.Record, labelNameId, sloId, a.ConsumedBudgetRatio())
+	expr := fmt.Sprintf("%s <= 1 - %g", g.naming.seriesSelector(errorBudgetRule), a.ConsumedBudgetRatio())
+	expr, err = g.guardExpr(sloId, expr, objective.Window(), a.MinEvents())
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlertingRule{
+		Alert:       alerter.Name(),
+		Expr:        expr,
+		Labels:      alerter.Labels(),
+		Annotations: alerter.Annotations(),
+	}, nil
+}
+
+func (g *RuleGenerator) generateErrorBudgetForecastAlertingRule(
+	sloId string,
+	objective *spec.Objective,
+	a *spec.ErrorBudgetForecastAlert,
+) (*AlertingRule, error) {
+	alerter, ok := a.Alerter().(*spec.PrometheusAlerter)
+	if !ok {
+		return nil, fmt.Errorf("only prometheus alerter is supported")
+	}
+
+	errorBudgetRule, err := g.getErrorBudgetRecordingRule(sloId)
+	if err != nil {
+		return nil, fmt.Errorf("could not find an error budget recording rule for the error budget forecast alert rule: %w", err)
+	}
+
+	var horizon string
+	switch w := objective.Window().(type) {
+	case *spec.RollingWindow:
+		if a.Horizon() == 0 {
+			return nil, fmt.Errorf("horizon must be specified for rolling objectives")
+		}
+		horizon = seconds(a.Horizon())
+	case *spec.CalendarWindow:
+		if a.Horizon() != 0 {
+			horizon = seconds(a.Horizon())
+		} else {
+			// Seconds remaining until the end of the current calendar window.
+			duration := seconds(w.Duration())
+			horizon = fmt.Sprintf("%s - (time() - %d) %% %s", duration, w.Start().Unix(), duration)
+		}
+	}
+
+	errorBudgetQuery := g.naming.seriesSelector(errorBudgetRule)
+	expr := fmt.Sprintf(
+		"predict_linear(%[1]s[%[2]s], %[3]s) <= 0 and %[1]s > 0",
+		errorBudgetQuery,
+		a.Lookback().String(),
+		horizon,
+	)
 
 	return &AlertingRule{
 		Alert:       alerter.Name(),
@@ -348,6 +911,56 @@ func (g *RuleGenerator) generateErrorBudgetAlertingRule(
 	}, nil
 }
 
+// generateSLIAbsentAlertingRule generates an alerting rule that fires when the error rate over window is not recorded.
+// NaN, which results from division by zero when there are no events, is also regarded as absent.
+func (g *RuleGenerator) generateSLIAbsentAlertingRule(
+	specName string,
+	sloName string,
+	window spec.Window,
+	a *spec.SLIAbsentAlert,
+) (*AlertingRule, error) {
+	alerter, ok := a.Alerter().(*spec.PrometheusAlerter)
+	if !ok {
+		return nil, fmt.Errorf("only prometheus alerter is supported")
+	}
+
+	sloId := g.naming.SLOId(specName, sloName)
+	errorRateRule, err := g.getErrorRateRecordingRule(sloId, window.Name())
+	if err != nil {
+		return nil, fmt.Errorf("could not find an error rate recording rule for the SLI absence alert rule: %w", err)
+	}
+
+	// absent() cannot derive labels from the comparison, so the labels identifying the SLO are attached explicitly.
+	labels := maps.Clone(alerter.Labels())
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[g.naming.LabelNameSpec()] = specName
+	labels[g.naming.LabelNameSLO()] = sloName
+	labels[g.naming.LabelNameId()] = sloId
+
+	return &AlertingRule{
+		Alert:       alerter.Name(),
+		Expr:        fmt.Sprintf("absent(%s >= 0)", g.naming.seriesSelector(errorRateRule)),
+		For:         a.For(),
+		Labels:      labels,
+		Annotations: alerter.Annotations(),
+	}, nil
+}
+
+func shortestRollingWindow(windows []spec.Window) spec.Window {
+	var shortest spec.Window
+	for _, w := range windows {
+		if _, ok := w.(*spec.RollingWindow); !ok {
+			continue
+		}
+		if shortest == nil || w.Duration() < shortest.Duration() {
+			shortest = w
+		}
+	}
+	return shortest
+}
+
 func (g *RuleGenerator) addAlertingRule(
 	sloId string,
 	r *AlertingRule,
@@ -0,0 +1,47 @@
+package series
+
+import (
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// SeriesSet is a set of generated time series.
+type SeriesSet struct {
+	// Start is the time of the first samples.
+	Start time.Time `json:"start" yaml:"start"`
+	// End is the time when the series end. Samples are generated before End.
+	End time.Time `json:"end" yaml:"end"`
+	// Interval is the interval between samples.
+	Interval model.Duration `json:"interval" yaml:"interval"`
+	// MetricFamilies are the metric families of the series.
+	MetricFamilies []*MetricFamily `json:"metricFamilies" yaml:"metricFamilies"`
+}
+
+// MetricFamily is a set of time series that share the same metric name.
+type MetricFamily struct {
+	// Name is the metric name.
+	Name string `json:"name" yaml:"name"`
+	// Help is the description of the metric.
+	Help string `json:"help" yaml:"help"`
+	// Type is the metric type (e.g., counter).
+	Type string `json:"type" yaml:"type"`
+	// Series are the time series in the metric family.
+	Series []*Series `json:"series" yaml:"series"`
+}
+
+// Series is a generated time series.
+type Series struct {
+	// Labels are the labels of the series excluding the metric name.
+	Labels map[string]string `json:"labels" yaml:"labels"`
+	// Samples are the samples of the series in chronological order.
+	Samples []Sample `json:"samples" yaml:"samples"`
+}
+
+// Sample is a sample of a time series.
+type Sample struct {
+	// Timestamp is the time of the sample in Unix seconds.
+	Timestamp int64 `json:"timestamp" yaml:"timestamp"`
+	// Value is the value of the sample.
+	Value float64 `json:"value" yaml:"value"`
+}
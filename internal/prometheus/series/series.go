@@ -24,6 +24,12 @@ type seriesGenerator interface {
 		end time.Time,
 		interval time.Duration,
 	) []series
+
+	generateSeries(
+		start time.Time,
+		end time.Time,
+		interval time.Duration,
+	) []*Series
 }
 
 type metricFamilyGenerator struct {
@@ -63,6 +69,22 @@ func (g *metricFamilyGenerator) generateUnitTestSeries(
 	return series
 }
 
+func (g *metricFamilyGenerator) generateMetricFamily(
+	start time.Time,
+	end time.Time,
+	interval time.Duration,
+) *MetricFamily {
+	mf := &MetricFamily{
+		Name: g.name,
+		Help: g.help,
+		Type: "counter",
+	}
+	for _, sg := range g.seriesGenerators {
+		mf.Series = append(mf.Series, sg.generateSeries(start, end, interval)...)
+	}
+	return mf
+}
+
 type SeriesSetGenerator struct {
 	start                  time.Time
 	end                    time.Time
@@ -177,6 +199,19 @@ func (g *SeriesSetGenerator) GenerateUnitTest(
 	return encoder.Encode(&unitTestFile)
 }
 
+// Generate generates the series set as a data structure, which can be printed in arbitrary formats.
+func (g *SeriesSetGenerator) Generate() *SeriesSet {
+	seriesSet := &SeriesSet{
+		Start:    g.start,
+		End:      g.end,
+		Interval: model.Duration(g.interval),
+	}
+	for _, mfg := range g.metricFamilyGenerators {
+		seriesSet.MetricFamilies = append(seriesSet.MetricFamilies, mfg.generateMetricFamily(g.start, g.end, g.interval))
+	}
+	return seriesSet
+}
+
 func (g *SeriesSetGenerator) Start() time.Time {
 	return g.start
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -219,3 +220,34 @@ func (g *successFailureSeriesGenerator) generateUnitTestSeries(
 	}
 	return []series{seriesSuccess, seriesFailure}
 }
+
+func (g *successFailureSeriesGenerator) generateSeries(
+	start time.Time,
+	end time.Time,
+	interval time.Duration,
+) []*Series {
+	labelsSuccess := maps.Clone(g.labels)
+	if labelsSuccess == nil {
+		labelsSuccess = make(map[string]string)
+	}
+	labelsFailure := maps.Clone(labelsSuccess)
+	labelsSuccess[g.labelNameStatus] = g.labelValueSuccess
+	labelsFailure[g.labelNameStatus] = g.labelValueFailure
+
+	seriesSuccess := &Series{Labels: labelsSuccess}
+	seriesFailure := &Series{Labels: labelsFailure}
+	g.metricPointsGenerator.generate(
+		start,
+		end,
+		interval,
+		func(v int, t int64) error {
+			seriesSuccess.Samples = append(seriesSuccess.Samples, Sample{Timestamp: t, Value: float64(v)})
+			return nil
+		},
+		func(v int, t int64) error {
+			seriesFailure.Samples = append(seriesFailure.Samples, Sample{Timestamp: t, Value: float64(v)})
+			return nil
+		},
+	)
+	return []*Series{seriesSuccess, seriesFailure}
+}
@@ -0,0 +1,216 @@
+package serve
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ajalab/slom/internal/document"
+	"github.com/ajalab/slom/internal/generate"
+	"github.com/ajalab/slom/internal/print"
+	"github.com/ajalab/slom/internal/prometheus/rule"
+	"github.com/ajalab/slom/internal/spec"
+)
+
+//go:embed templates/*.html
+var templates embed.FS
+
+// newPageTemplates parses page templates together with the built-in HTML document template
+// so that pages can embed SLO documents.
+func newPageTemplates() (*template.Template, error) {
+	documentTemplate, err := document.Template("html")
+	if err != nil {
+		return nil, err
+	}
+	t, err := template.New("").Funcs(template.FuncMap(print.FuncMap())).Parse(string(documentTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the document template: %w", err)
+	}
+	t, err = t.ParseFS(templates, "templates/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the page templates: %w", err)
+	}
+	return t, nil
+}
+
+var specFileExtensions = []string{".yaml", ".yml"}
+
+// Handler serves SLO documents, Prometheus rules and specs generated from the spec files in a directory.
+type Handler struct {
+	dir       string
+	mux       *http.ServeMux
+	templates *template.Template
+}
+
+var _ http.Handler = &Handler{}
+
+// NewHandler creates a handler that serves the spec files in dir.
+func NewHandler(dir string) (*Handler, error) {
+	pageTemplates, err := newPageTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handler{
+		dir:       dir,
+		mux:       http.NewServeMux(),
+		templates: pageTemplates,
+	}
+	h.mux.HandleFunc("GET /{$}", h.handleIndex)
+	h.mux.HandleFunc("GET /specs/{name}", h.handleDocument)
+	h.mux.HandleFunc("GET /specs/{name}/document.json", h.handleDocumentJSON)
+	h.mux.HandleFunc("GET /specs/{name}/rules.yaml", h.handleRules)
+	h.mux.HandleFunc("GET /specs/{name}/spec.json", h.handleSpec)
+
+	return h, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) handleIndex(w http.ResponseWriter, r *http.Request) {
+	names, err := h.specNames()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to list spec files: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderHTML(w, "index.html", struct {
+		Dir   string
+		Names []string
+	}{h.dir, names})
+}
+
+func (h *Handler) handleDocument(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s, ok := h.loadSpec(w, name)
+	if !ok {
+		return
+	}
+
+	h.renderHTML(w, "document.html", struct {
+		FileName string
+		Document *document.Document
+	}{name, document.ToDocument(s)})
+}
+
+func (h *Handler) handleDocumentJSON(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.loadSpec(w, r.PathValue("name"))
+	if !ok {
+		return
+	}
+
+	h.render(w, "application/json", "json", document.ToDocument(s))
+}
+
+func (h *Handler) handleRules(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.loadSpec(w, r.PathValue("name"))
+	if !ok {
+		return
+	}
+
+	g := rule.NewRuleGenerator(nil)
+	if err := g.GenerateRecordingRules(s); err != nil {
+		http.Error(w, fmt.Sprintf("failed to generate recording rule groups: %s", err), http.StatusUnprocessableEntity)
+		return
+	}
+	if err := g.GenerateAlertingRules(s); err != nil {
+		http.Error(w, fmt.Sprintf("failed to generate alerting rule groups: %s", err), http.StatusUnprocessableEntity)
+		return
+	}
+	prometheusRuleGroups := g.RuleGroups().Prometheus()
+
+	h.render(w, "application/yaml", "yaml", &prometheusRuleGroups)
+}
+
+func (h *Handler) handleSpec(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.loadSpec(w, r.PathValue("name"))
+	if !ok {
+		return
+	}
+
+	h.render(w, "application/json", "json", s)
+}
+
+func (h *Handler) specNames() ([]string, error) {
+	entries, err := os.ReadDir(h.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || !slices.Contains(specFileExtensions, ext) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ext))
+	}
+	return names, nil
+}
+
+// loadSpec loads the spec named name. If it fails, an error response is written and false is returned.
+func (h *Handler) loadSpec(w http.ResponseWriter, name string) (*spec.Spec, bool) {
+	// Names are decoded from the path, so they must not refer to files outside the directory (e.g., "..%2Fsecret").
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		http.Error(w, fmt.Sprintf("spec %s is not found", name), http.StatusNotFound)
+		return nil, false
+	}
+
+	for _, ext := range specFileExtensions {
+		fileName := filepath.Join(h.dir, name+ext)
+		if _, err := os.Stat(fileName); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		s, err := generate.LoadSpec(fileName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return nil, false
+		}
+		return s, true
+	}
+
+	http.Error(w, fmt.Sprintf("spec %s is not found", name), http.StatusNotFound)
+	return nil, false
+}
+
+func (h *Handler) renderHTML(w http.ResponseWriter, name string, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to render %s: %s", name, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	buf.WriteTo(w)
+}
+
+func (h *Handler) render(w http.ResponseWriter, contentType string, output string, v any) {
+	var buf bytes.Buffer
+	printer, err := print.NewPrinter(&buf, output)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get a printer: %s", err), http.StatusInternalServerError)
+		return
+	}
+	if err := printer.Print(v); err != nil {
+		http.Error(w, fmt.Sprintf("failed to print: %s", err), http.StatusInternalServerError)
+		return
+	}
+	if err := printer.Close(); err != nil {
+		http.Error(w, fmt.Sprintf("failed to print: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	buf.WriteTo(w)
+}
@@ -0,0 +1,157 @@
+package serve
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSpec = `name: test
+slos:
+  - name: availability
+    objective:
+      ratio: 0.99
+      windowRef: window-4w
+    indicator:
+      prometheus:
+        errorRatio: >-
+          sum by (job) (rate(http_requests_total{job="foo", code!~"2.."}[$window])) /
+          sum by (job) (rate(http_requests_total{job="foo"}[$window]))
+        level:
+          - job
+    windows:
+      - name: window-4w
+        rolling:
+          duration: 4w
+`
+
+func newTestServer(t *testing.T) (*httptest.Server, string) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(testSpec), 0o644); err != nil {
+		t.Fatalf("failed to write a spec file: %v", err)
+	}
+
+	handler, err := NewHandler(dir)
+	if err != nil {
+		t.Fatalf("failed to create a handler: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server, dir
+}
+
+func get(t *testing.T, url string) (int, string, string) {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("failed to get %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the response body of %s: %v", url, err)
+	}
+	return resp.StatusCode, resp.Header.Get("Content-Type"), string(body)
+}
+
+func TestHandler(t *testing.T) {
+	server, dir := newTestServer(t)
+
+	type tc struct {
+		path        string
+		status      int
+		contentType string
+		contains    string
+	}
+	tcs := []tc{
+		{"/", http.StatusOK, "text/html; charset=utf-8", `<a href="/specs/test">test</a>`},
+		{"/specs/test", http.StatusOK, "text/html; charset=utf-8", "SLO: availability"},
+		{"/specs/test/document.json", http.StatusOK, "application/json", `"name": "availability"`},
+		{"/specs/test/rules.yaml", http.StatusOK, "application/yaml", "record: job:slom_error:ratio_rate4w"},
+		{"/specs/test/spec.json", http.StatusOK, "application/json", `"windowRef": "window-4w"`},
+		{"/specs/unknown", http.StatusNotFound, "", ""},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.path, func(t *testing.T) {
+			status, contentType, body := get(t, server.URL+tc.path)
+			if status != tc.status {
+				t.Fatalf("unexpected status code. expected=%d, actual=%d, body=%s", tc.status, status, body)
+			}
+			if tc.contentType != "" && contentType != tc.contentType {
+				t.Errorf("unexpected content type. expected=%s, actual=%s", tc.contentType, contentType)
+			}
+			if !strings.Contains(body, tc.contains) {
+				t.Errorf("the response body doesn't contain %q: %s", tc.contains, body)
+			}
+		})
+	}
+
+	t.Run("spec.json", func(t *testing.T) {
+		_, _, body := get(t, server.URL+"/specs/test/spec.json")
+		var s struct {
+			Name string `json:"name"`
+			SLOs []struct {
+				Name string `json:"name"`
+			} `json:"slos"`
+		}
+		if err := json.Unmarshal([]byte(body), &s); err != nil {
+			t.Fatalf("failed to decode spec JSON: %v", err)
+		}
+		if s.Name != "test" || len(s.SLOs) != 1 || s.SLOs[0].Name != "availability" {
+			t.Errorf("unexpected spec JSON: %s", body)
+		}
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		updated := strings.Replace(testSpec, "name: availability", "name: latency", 1)
+		if err := os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(updated), 0o644); err != nil {
+			t.Fatalf("failed to update a spec file: %v", err)
+		}
+		_, _, body := get(t, server.URL+"/specs/test")
+		if !strings.Contains(body, "SLO: latency") {
+			t.Errorf("the document doesn't reflect the updated spec: %s", body)
+		}
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("slos: ["), 0o644); err != nil {
+			t.Fatalf("failed to write a spec file: %v", err)
+		}
+		status, _, _ := get(t, server.URL+"/specs/invalid")
+		if status != http.StatusUnprocessableEntity {
+			t.Errorf("unexpected status code. expected=%d, actual=%d", http.StatusUnprocessableEntity, status)
+		}
+	})
+}
+
+func TestHandlerPathTraversal(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "specs")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatalf("failed to create a directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "secret.yaml"), []byte(testSpec), 0o644); err != nil {
+		t.Fatalf("failed to write a spec file: %v", err)
+	}
+
+	handler, err := NewHandler(dir)
+	if err != nil {
+		t.Fatalf("failed to create a handler: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	for _, path := range []string{"/specs/..%2Fsecret", "/specs/..%2Fsecret/spec.json", "/specs/..%5Csecret"} {
+		t.Run(path, func(t *testing.T) {
+			status, _, body := get(t, server.URL+path)
+			if status != http.StatusNotFound {
+				t.Errorf("unexpected status code. expected=%d, actual=%d, body=%s", http.StatusNotFound, status, body)
+			}
+		})
+	}
+}
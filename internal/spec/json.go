@@ -0,0 +1,207 @@
+package spec
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The JSON representation of spec follows the structure of spec configs
+// except that windows are referred to by their names.
+
+func (s *Spec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Version     string            `json:"version,omitempty"`
+		Name        string            `json:"name"`
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+		SLOs        []*SLO            `json:"slos"`
+	}{s.version, s.name, s.labels, s.annotations, s.slos})
+}
+
+func (s *SLO) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name        string            `json:"name"`
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+		Objective   *Objective        `json:"objective"`
+		Indicator   Indicator         `json:"indicator"`
+		Alerts      []Alert           `json:"alerts"`
+		Windows     []Window          `json:"windows"`
+		Template    string            `json:"template,omitempty"`
+		Matrix      map[string]string `json:"matrix,omitempty"`
+	}{s.name, s.labels, s.annotations, s.objective, s.indicator, s.alerts, s.windows, s.template, s.matrix})
+}
+
+func (o *Objective) MarshalJSON() ([]byte, error) {
+	type ratioByLabel struct {
+		Label  string             `json:"label"`
+		Ratios map[string]float64 `json:"ratios"`
+	}
+	var rbl *ratioByLabel
+	if o.ratioLabel != "" {
+		rbl = &ratioByLabel{o.ratioLabel, o.labelRatios}
+	}
+	return json.Marshal(struct {
+		Ratio        float64       `json:"ratio"`
+		WindowRef    string        `json:"windowRef,omitempty"`
+		RatioByLabel *ratioByLabel `json:"ratioByLabel,omitempty"`
+	}{o.ratio, windowRef(o.window), rbl})
+}
+
+func (pi *PrometheusIndicator) MarshalJSON() ([]byte, error) {
+	type prometheus struct {
+		ErrorRatio  string   `json:"errorRatio"`
+		Level       []string `json:"level"`
+		TotalEvents string   `json:"totalEvents,omitempty"`
+	}
+	return json.Marshal(struct {
+		Prometheus prometheus `json:"prometheus"`
+	}{prometheus{pi.errorRatio, pi.level, pi.totalEvents}})
+}
+
+func (di *DatadogIndicator) MarshalJSON() ([]byte, error) {
+	type datadog struct {
+		Good  string `json:"good"`
+		Total string `json:"total"`
+	}
+	return json.Marshal(struct {
+		Datadog datadog `json:"datadog"`
+	}{datadog{di.good, di.total}})
+}
+
+func (gi *GoogleCloudMonitoringIndicator) MarshalJSON() ([]byte, error) {
+	type googleCloudMonitoring struct {
+		Service            string `json:"service"`
+		GoodServiceFilter  string `json:"goodServiceFilter,omitempty"`
+		BadServiceFilter   string `json:"badServiceFilter,omitempty"`
+		TotalServiceFilter string `json:"totalServiceFilter,omitempty"`
+	}
+	return json.Marshal(struct {
+		GoogleCloudMonitoring googleCloudMonitoring `json:"googleCloudMonitoring"`
+	}{googleCloudMonitoring{gi.service, gi.goodServiceFilter, gi.badServiceFilter, gi.totalServiceFilter}})
+}
+
+func (pw *PrometheusWindow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		EvaluationInterval Duration `json:"evaluationInterval"`
+	}{pw.evaluationInterval})
+}
+
+func (w *RollingWindow) MarshalJSON() ([]byte, error) {
+	type rolling struct {
+		Duration Duration `json:"duration"`
+	}
+	return json.Marshal(struct {
+		Name       string            `json:"name"`
+		Rolling    rolling           `json:"rolling"`
+		Prometheus *PrometheusWindow `json:"prometheus"`
+	}{w.name, rolling{w.duration}, w.prometheus})
+}
+
+func (w *CalendarWindow) MarshalJSON() ([]byte, error) {
+	type calendar struct {
+		Duration Duration `json:"duration"`
+		Start    string   `json:"start"`
+	}
+	return json.Marshal(struct {
+		Name       string            `json:"name"`
+		Calendar   calendar          `json:"calendar"`
+		Prometheus *PrometheusWindow `json:"prometheus"`
+	}{w.name, calendar{w.duration, w.start.Format(time.DateTime)}, w.prometheus})
+}
+
+func (a *BurnRateAlert) MarshalJSON() ([]byte, error) {
+	type burnRate struct {
+		ConsumedBudgetRatio float64                    `json:"consumedBudgetRatio"`
+		SingleWindow        *BurnRateAlertSingleWindow `json:"singleWindow,omitempty"`
+		MultiWindows        *BurnRateAlertMultiWindows `json:"multiWindows,omitempty"`
+		MinEvents           int                        `json:"minEvents,omitempty"`
+	}
+	b := burnRate{ConsumedBudgetRatio: a.consumedBudgetRatio, MinEvents: a.minEvents}
+	switch w := a.window.(type) {
+	case *BurnRateAlertSingleWindow:
+		b.SingleWindow = w
+	case *BurnRateAlertMultiWindows:
+		b.MultiWindows = w
+	}
+
+	return json.Marshal(struct {
+		Name     string   `json:"name"`
+		BurnRate burnRate `json:"burnRate"`
+		Alerter  Alerter  `json:"alerter"`
+	}{a.name, b, a.alerter})
+}
+
+func (w *BurnRateAlertSingleWindow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		WindowRef string `json:"windowRef"`
+	}{windowRef(w.window)})
+}
+
+func (w *BurnRateAlertMultiWindows) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ShortWindowRef string `json:"shortWindowRef"`
+		LongWindowRef  string `json:"longWindowRef"`
+	}{windowRef(w.shortWindow), windowRef(w.longWindow)})
+}
+
+func (a *ErrorBudgetAlert) MarshalJSON() ([]byte, error) {
+	type errorBudget struct {
+		ConsumedBudgetRatio float64 `json:"consumedBudgetRatio"`
+		MinEvents           int     `json:"minEvents,omitempty"`
+	}
+	return json.Marshal(struct {
+		Name        string      `json:"name"`
+		ErrorBudget errorBudget `json:"errorBudget"`
+		Alerter     Alerter     `json:"alerter"`
+	}{a.name, errorBudget{a.consumedBudgetRatio, a.minEvents}, a.alerter})
+}
+
+func (a *ErrorBudgetForecastAlert) MarshalJSON() ([]byte, error) {
+	type errorBudgetForecast struct {
+		Lookback Duration  `json:"lookback"`
+		Horizon  *Duration `json:"horizon,omitempty"`
+	}
+	f := errorBudgetForecast{Lookback: a.lookback}
+	if a.horizon != 0 {
+		f.Horizon = &a.horizon
+	}
+	return json.Marshal(struct {
+		Name                string              `json:"name"`
+		ErrorBudgetForecast errorBudgetForecast `json:"errorBudgetForecast"`
+		Alerter             Alerter             `json:"alerter"`
+	}{a.name, f, a.alerter})
+}
+
+func (a *SLIAbsentAlert) MarshalJSON() ([]byte, error) {
+	type sliAbsent struct {
+		For *Duration `json:"for,omitempty"`
+	}
+	var f sliAbsent
+	if a.forDuration != 0 {
+		f.For = &a.forDuration
+	}
+	return json.Marshal(struct {
+		Name      string    `json:"name"`
+		SLIAbsent sliAbsent `json:"sliAbsent"`
+		Alerter   Alerter   `json:"alerter"`
+	}{a.name, f, a.alerter})
+}
+
+func (a *PrometheusAlerter) MarshalJSON() ([]byte, error) {
+	type prometheus struct {
+		Name        string            `json:"name"`
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	}
+	return json.Marshal(struct {
+		Prometheus prometheus `json:"prometheus"`
+	}{prometheus{a.name, a.labels, a.annotations}})
+}
+
+func windowRef(w Window) string {
+	if w == nil {
+		return ""
+	}
+	return w.Name()
+}
@@ -0,0 +1,108 @@
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	native "github.com/ajalab/slom/internal/config/spec/native/v1alpha"
+	"gopkg.in/yaml.v3"
+)
+
+var reMatrixPlaceholder = regexp.MustCompile(`\$\{matrix\.([^}]*)\}`)
+
+// expandedSLOConfig is an SLO configuration expanded from a template.
+type expandedSLOConfig struct {
+	config   native.SLOConfig
+	template string
+	matrix   map[string]string
+}
+
+// expandSLOConfig expands an SLO configuration with a matrix into an SLO configuration for each combination of the values.
+// The combinations are ordered by the keys of the matrix and then by the order of the values.
+// An SLO configuration without a matrix is returned as it is.
+func expandSLOConfig(c *native.SLOConfig) ([]expandedSLOConfig, error) {
+	if len(c.Matrix) == 0 {
+		return []expandedSLOConfig{{config: *c}}, nil
+	}
+
+	keys := slices.Sorted(maps.Keys(c.Matrix))
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("matrix keys must not be empty")
+		}
+		if len(c.Matrix[key]) == 0 {
+			return nil, fmt.Errorf("matrix key \"%s\" has no values", key)
+		}
+	}
+
+	template := *c
+	template.Matrix = nil
+
+	var expanded []expandedSLOConfig
+	names := make(map[string]struct{})
+	for _, params := range matrixCombinations(keys, c.Matrix) {
+		var node yaml.Node
+		if err := node.Encode(&template); err != nil {
+			return nil, fmt.Errorf("failed to encode the SLO template: %w", err)
+		}
+		if err := interpolateMatrix(&node, params); err != nil {
+			return nil, err
+		}
+
+		var config native.SLOConfig
+		if err := node.Decode(&config); err != nil {
+			return nil, fmt.Errorf("failed to decode the SLO template expanded with %v: %w", params, err)
+		}
+		if _, ok := names[config.Name]; ok {
+			return nil, fmt.Errorf("SLOs expanded from the template have the same name \"%s\"", config.Name)
+		}
+		names[config.Name] = struct{}{}
+
+		expanded = append(expanded, expandedSLOConfig{
+			config:   config,
+			template: c.Name,
+			matrix:   params,
+		})
+	}
+	return expanded, nil
+}
+
+func matrixCombinations(keys []string, matrix map[string][]string) []map[string]string {
+	combinations := []map[string]string{{}}
+	for _, key := range keys {
+		var next []map[string]string
+		for _, combination := range combinations {
+			for _, value := range matrix[key] {
+				params := maps.Clone(combination)
+				params[key] = value
+				next = append(next, params)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// interpolateMatrix replaces the matrix placeholders in the string scalars in node and its descendants.
+func interpolateMatrix(node *yaml.Node, params map[string]string) error {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		var err error
+		node.Value = reMatrixPlaceholder.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+			key := reMatrixPlaceholder.FindStringSubmatch(placeholder)[1]
+			value, ok := params[key]
+			if !ok && err == nil {
+				err = fmt.Errorf("matrix has no key \"%s\" referred by %s", key, placeholder)
+			}
+			return value
+		})
+		return err
+	}
+	for _, n := range node.Content {
+		if err := interpolateMatrix(n, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,17 +1,38 @@
 package spec
 
 import (
+	"math"
 	"time"
 
+	core "github.com/ajalab/slom/internal/config/spec/core/v1alpha"
 	"github.com/prometheus/common/model"
 )
 
 type Duration = model.Duration
 
+// RoundRatio rounds a ratio to remove floating point errors such as 1 - 0.999 = 0.0010000000000000009.
+func RoundRatio(r float64) float64 {
+	return math.Round(r*1e12) / 1e12
+}
+
+// FormatDuration formats nanoseconds as a duration rounded to seconds or milliseconds (e.g., 40m19s).
+func FormatDuration(ns float64) string {
+	d := time.Duration(ns)
+	if d >= time.Minute {
+		d = d.Round(time.Second)
+	} else {
+		d = d.Round(time.Millisecond)
+	}
+	return Duration(d).String()
+}
+
 type Spec struct {
 	name        string
+	version     string
+	hash        string
 	labels      map[string]string
 	annotations map[string]string
+	naming      *core.NamingConfig
 	slos        []*SLO
 }
 
@@ -19,6 +40,16 @@ func (s *Spec) Name() string {
 	return s.name
 }
 
+// Version returns the version of the configuration schema of the spec.
+func (s *Spec) Version() string {
+	return s.version
+}
+
+// Hash returns a hash of the spec that changes when the content of the spec changes.
+func (s *Spec) Hash() string {
+	return s.hash
+}
+
 func (s *Spec) Labels() map[string]string {
 	return s.labels
 }
@@ -27,6 +58,11 @@ func (s *Spec) Annotations() map[string]string {
 	return s.annotations
 }
 
+// Naming returns the naming config of the spec, or nil if the spec uses the default names.
+func (s *Spec) Naming() *core.NamingConfig {
+	return s.naming
+}
+
 func (s *Spec) SLOs() []*SLO {
 	return s.slos
 }
@@ -39,12 +75,24 @@ type SLO struct {
 	indicator   Indicator
 	alerts      []Alert
 	windows     []Window
+	template    string
+	matrix      map[string]string
 }
 
 func (s *SLO) Name() string {
 	return s.name
 }
 
+// Template returns the name of the SLO template that the SLO is expanded from, or an empty string if it isn't.
+func (s *SLO) Template() string {
+	return s.template
+}
+
+// Matrix returns the values of the matrix keys that the SLO is expanded with.
+func (s *SLO) Matrix() map[string]string {
+	return s.matrix
+}
+
 func (s *SLO) Labels() map[string]string {
 	return s.labels
 }
@@ -70,14 +118,27 @@ func (s *SLO) Windows() []Window {
 }
 
 type Objective struct {
-	ratio  float64
-	window Window
+	ratio       float64
+	ratioLabel  string
+	labelRatios map[string]float64
+	window      Window
 }
 
+// Ratio returns the target ratio for the label values without their own ratios.
 func (o *Objective) Ratio() float64 {
 	return o.ratio
 }
 
+// RatioLabel returns the name of the label whose values have their own target ratios, if any.
+func (o *Objective) RatioLabel() string {
+	return o.ratioLabel
+}
+
+// LabelRatios returns the target ratios keyed by the values of RatioLabel.
+func (o *Objective) LabelRatios() map[string]float64 {
+	return o.labelRatios
+}
+
 func (o *Objective) Window() Window {
 	return o.window
 }
@@ -86,8 +147,9 @@ type Indicator interface {
 }
 
 type PrometheusIndicator struct {
-	errorRatio string
-	level      []string
+	errorRatio  string
+	level       []string
+	totalEvents string
 }
 
 func (pi *PrometheusIndicator) ErrorRatio() string {
@@ -98,6 +160,47 @@ func (pi *PrometheusIndicator) Level() []string {
 	return pi.level
 }
 
+// TotalEvents returns a PromQL query that computes the number of events over $window, or an empty string.
+func (pi *PrometheusIndicator) TotalEvents() string {
+	return pi.totalEvents
+}
+
+type DatadogIndicator struct {
+	good  string
+	total string
+}
+
+func (di *DatadogIndicator) Good() string {
+	return di.good
+}
+
+func (di *DatadogIndicator) Total() string {
+	return di.total
+}
+
+type GoogleCloudMonitoringIndicator struct {
+	service            string
+	goodServiceFilter  string
+	badServiceFilter   string
+	totalServiceFilter string
+}
+
+func (gi *GoogleCloudMonitoringIndicator) Service() string {
+	return gi.service
+}
+
+func (gi *GoogleCloudMonitoringIndicator) GoodServiceFilter() string {
+	return gi.goodServiceFilter
+}
+
+func (gi *GoogleCloudMonitoringIndicator) BadServiceFilter() string {
+	return gi.badServiceFilter
+}
+
+func (gi *GoogleCloudMonitoringIndicator) TotalServiceFilter() string {
+	return gi.totalServiceFilter
+}
+
 type PrometheusWindow struct {
 	evaluationInterval Duration
 }
@@ -162,14 +265,22 @@ type Alert interface {
 	Alerter() Alerter
 }
 
+// GuardedAlert is an alert that fires only when enough events happened over its window.
+type GuardedAlert interface {
+	Alert
+	// MinEvents returns the minimum number of events for the alert to fire. Zero means no guard.
+	MinEvents() int
+}
+
 type BurnRateAlert struct {
 	name                string
 	consumedBudgetRatio float64
 	window              BurnRateAlertWindow
+	minEvents           int
 	alerter             Alerter
 }
 
-var _ Alert = &BurnRateAlert{}
+var _ GuardedAlert = &BurnRateAlert{}
 
 func (a *BurnRateAlert) Name() string {
 	return a.name
@@ -183,6 +294,15 @@ func (a *BurnRateAlert) Window() BurnRateAlertWindow {
 	return a.window
 }
 
+// BurnRateThreshold returns the error budget burn rate at which the alert fires for sloWindow.
+func (a *BurnRateAlert) BurnRateThreshold(sloWindow Window) float64 {
+	return a.consumedBudgetRatio * float64(sloWindow.Duration()) / float64(a.window.Window().Duration())
+}
+
+func (a *BurnRateAlert) MinEvents() int {
+	return a.minEvents
+}
+
 func (a *BurnRateAlert) Alerter() Alerter {
 	return a.alerter
 }
@@ -223,10 +343,11 @@ func (w *BurnRateAlertMultiWindows) LongWindow() Window {
 type ErrorBudgetAlert struct {
 	name                string
 	consumedBudgetRatio float64
+	minEvents           int
 	alerter             Alerter
 }
 
-var _ Alert = &ErrorBudgetAlert{}
+var _ GuardedAlert = &ErrorBudgetAlert{}
 
 func (a *ErrorBudgetAlert) Name() string {
 	return a.name
@@ -236,10 +357,63 @@ func (a *ErrorBudgetAlert) ConsumedBudgetRatio() float64 {
 	return a.consumedBudgetRatio
 }
 
+func (a *ErrorBudgetAlert) MinEvents() int {
+	return a.minEvents
+}
+
 func (a *ErrorBudgetAlert) Alerter() Alerter {
 	return a.alerter
 }
 
+// ErrorBudgetForecastAlert is an alert that fires when the error budget is predicted to run out.
+type ErrorBudgetForecastAlert struct {
+	name     string
+	lookback Duration
+	horizon  Duration
+	alerter  Alerter
+}
+
+var _ Alert = &ErrorBudgetForecastAlert{}
+
+func (a *ErrorBudgetForecastAlert) Name() string {
+	return a.name
+}
+
+func (a *ErrorBudgetForecastAlert) Lookback() Duration {
+	return a.lookback
+}
+
+// Horizon returns how far ahead the error budget is forecast, or zero for the end of the calendar window.
+func (a *ErrorBudgetForecastAlert) Horizon() Duration {
+	return a.horizon
+}
+
+func (a *ErrorBudgetForecastAlert) Alerter() Alerter {
+	return a.alerter
+}
+
+// SLIAbsentAlert is an alert that fires when the SLI cannot be computed.
+type SLIAbsentAlert struct {
+	name        string
+	forDuration Duration
+	alerter     Alerter
+}
+
+var _ Alert = &SLIAbsentAlert{}
+
+func (a *SLIAbsentAlert) Name() string {
+	return a.name
+}
+
+// For returns how long the SLI must be absent before the alert fires.
+func (a *SLIAbsentAlert) For() Duration {
+	return a.forDuration
+}
+
+func (a *SLIAbsentAlert) Alerter() Alerter {
+	return a.alerter
+}
+
 type Alerter interface {
 }
 
@@ -1,7 +1,11 @@
 package spec
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	core "github.com/ajalab/slom/internal/config/spec/core/v1alpha"
@@ -33,20 +37,41 @@ func (sc *specContext) addAlert(alert Alert) error {
 func ToSpec(c *native.SpecConfig) (*Spec, error) {
 	var slos []*SLO
 	for _, s := range c.SLOs {
-		slo, err := toSLO(&s)
+		expanded, err := expandSLOConfig(&s)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert an SLO config \"%s\" into spec: %w", s.Name, err)
+			return nil, fmt.Errorf("failed to expand an SLO template \"%s\": %w", s.Name, err)
 		}
 
-		slos = append(slos, slo)
+		for _, e := range expanded {
+			slo, err := toSLO(&e.config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert an SLO config \"%s\" into spec: %w", e.config.Name, err)
+			}
+			slo.template = e.template
+			slo.matrix = e.matrix
+
+			slos = append(slos, slo)
+		}
 	}
 
-	return &Spec{
+	s := &Spec{
 		name:        c.Name,
+		version:     c.Version,
 		labels:      ensureMapNotNil(c.Labels),
 		annotations: ensureMapNotNil(c.Annotations),
+		naming:      c.Naming,
 		slos:        slos,
-	}, nil
+	}
+
+	// The hash is computed from the JSON representation so that it doesn't depend on the formatting of the config.
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the hash of spec: %w", err)
+	}
+	h := sha256.Sum256(b)
+	s.hash = hex.EncodeToString(h[:])[:12]
+
+	return s, nil
 }
 
 func toSLO(slo *native.SLOConfig) (*SLO, error) {
@@ -74,12 +99,22 @@ func toSLO(slo *native.SLOConfig) (*SLO, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert an indicator config s to spec: %w", err)
 	}
+	if objective.RatioLabel() != "" {
+		if pi, ok := indicator.(*PrometheusIndicator); !ok || !slices.Contains(pi.Level(), objective.RatioLabel()) {
+			return nil, fmt.Errorf("label \"%s\" in ratioByLabel must be in the level of the prometheus indicator", objective.RatioLabel())
+		}
+	}
 
 	for i, a := range slo.Alerts {
 		alert, err := toAlert(&sc, &a)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert an alert config \"%s\" (index %d) to spec: %w", a.Name, i, err)
 		}
+		if a, ok := alert.(GuardedAlert); ok && a.MinEvents() > 0 {
+			if pi, ok := indicator.(*PrometheusIndicator); !ok || pi.TotalEvents() == "" {
+				return nil, fmt.Errorf("alert config \"%s\" (index %d) has minEvents but the indicator has no totalEvents query", a.Name(), i)
+			}
+		}
 
 		if err := sc.addAlert(alert); err != nil {
 			return nil, err
@@ -110,17 +145,62 @@ func toObjective(
 		}
 	}
 
+	var ratioLabel string
+	var labelRatios map[string]float64
+	if rbl := objective.RatioByLabel; rbl != nil {
+		if rbl.Label == "" {
+			return nil, fmt.Errorf("label must be specified in ratioByLabel")
+		}
+		for value, ratio := range rbl.Ratios {
+			if ratio <= 0 || ratio >= 1 {
+				return nil, fmt.Errorf("ratio %g for %s=\"%s\" must be between 0 and 1", ratio, rbl.Label, value)
+			}
+		}
+		ratioLabel = rbl.Label
+		labelRatios = rbl.Ratios
+	}
+
 	return &Objective{
-		ratio:  objective.Ratio,
-		window: window,
+		ratio:       objective.Ratio,
+		ratioLabel:  ratioLabel,
+		labelRatios: labelRatios,
+		window:      window,
 	}, nil
 }
 
 func toIndicator(indicator *core.IndicatorConfig) (Indicator, error) {
-	if indicator.Prometheus != nil {
+	switch {
+	case indicator.Prometheus != nil && indicator.Datadog == nil && indicator.GoogleCloudMonitoring == nil:
 		return &PrometheusIndicator{
-			errorRatio: indicator.Prometheus.ErrorRatio,
-			level:      indicator.Prometheus.Level,
+			errorRatio:  indicator.Prometheus.ErrorRatio,
+			level:       indicator.Prometheus.Level,
+			totalEvents: indicator.Prometheus.TotalEvents,
+		}, nil
+	case indicator.Datadog != nil && indicator.Prometheus == nil && indicator.GoogleCloudMonitoring == nil:
+		d := indicator.Datadog
+		if d.Good == "" || d.Total == "" {
+			return nil, fmt.Errorf("both good and total must be specified in datadog indicator")
+		}
+		return &DatadogIndicator{
+			good:  d.Good,
+			total: d.Total,
+		}, nil
+	case indicator.GoogleCloudMonitoring != nil && indicator.Prometheus == nil && indicator.Datadog == nil:
+		g := indicator.GoogleCloudMonitoring
+		filters := 0
+		for _, f := range []string{g.GoodServiceFilter, g.BadServiceFilter, g.TotalServiceFilter} {
+			if f != "" {
+				filters++
+			}
+		}
+		if filters != 2 {
+			return nil, fmt.Errorf("two of goodServiceFilter, badServiceFilter and totalServiceFilter must be specified in googleCloudMonitoring indicator")
+		}
+		return &GoogleCloudMonitoringIndicator{
+			service:            g.Service,
+			goodServiceFilter:  g.GoodServiceFilter,
+			badServiceFilter:   g.BadServiceFilter,
+			totalServiceFilter: g.TotalServiceFilter,
 		}, nil
 	}
 
@@ -191,29 +271,85 @@ func toAlert(sc *specContext, alert *core.AlertConfig) (Alert, error) {
 		return nil, fmt.Errorf("failed to convert alerter config to spec: %w", err)
 	}
 
-	if alert.BurnRate != nil && alert.ErrorBudget == nil {
+	if alert.BurnRate != nil && alert.ErrorBudget == nil && alert.ErrorBudgetForecast == nil && alert.SLIAbsent == nil {
 		window, err := toBurnRateAlertWindow(sc, alert.BurnRate)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert burn rate alert window config to spec: %w", err)
 		}
+		if alert.BurnRate.MinEvents < 0 {
+			return nil, fmt.Errorf("minEvents must not be negative")
+		}
 		return &BurnRateAlert{
 			name:                alert.Name,
 			consumedBudgetRatio: alert.BurnRate.ConsumedBudgetRatio,
 			window:              window,
+			minEvents:           alert.BurnRate.MinEvents,
 			alerter:             alerter,
 		}, nil
 	}
 
-	if alert.ErrorBudget != nil && alert.BurnRate == nil {
+	if alert.ErrorBudget != nil && alert.BurnRate == nil && alert.ErrorBudgetForecast == nil && alert.SLIAbsent == nil {
+		if alert.ErrorBudget.MinEvents < 0 {
+			return nil, fmt.Errorf("minEvents must not be negative")
+		}
 		return &ErrorBudgetAlert{
 			name:                alert.Name,
 			consumedBudgetRatio: alert.ErrorBudget.ConsumedBudgetRatio,
+			minEvents:           alert.ErrorBudget.MinEvents,
 			alerter:             alerter,
 		}, nil
 	}
+
+	if alert.ErrorBudgetForecast != nil && alert.BurnRate == nil && alert.ErrorBudget == nil && alert.SLIAbsent == nil {
+		return toErrorBudgetForecastAlert(alert.Name, alert.ErrorBudgetForecast, alerter)
+	}
+
+	if alert.SLIAbsent != nil && alert.BurnRate == nil && alert.ErrorBudget == nil && alert.ErrorBudgetForecast == nil {
+		var forDuration Duration
+		if alert.SLIAbsent.For != "" {
+			forDuration, err = model.ParseDuration(alert.SLIAbsent.For)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse for \"%s\": %w", alert.SLIAbsent.For, err)
+			}
+		}
+		return &SLIAbsentAlert{
+			name:        alert.Name,
+			forDuration: forDuration,
+			alerter:     alerter,
+		}, nil
+	}
 	return nil, fmt.Errorf("either one of alert types must be implemented")
 }
 
+func toErrorBudgetForecastAlert(
+	name string,
+	a *core.ErrorBudgetForecastAlertConfig,
+	alerter Alerter,
+) (*ErrorBudgetForecastAlert, error) {
+	lookback, err := model.ParseDuration(a.Lookback)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lookback \"%s\": %w", a.Lookback, err)
+	}
+	if lookback <= 0 {
+		return nil, fmt.Errorf("lookback must be positive")
+	}
+
+	var horizon Duration
+	if a.Horizon != "" {
+		horizon, err = model.ParseDuration(a.Horizon)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse horizon \"%s\": %w", a.Horizon, err)
+		}
+	}
+
+	return &ErrorBudgetForecastAlert{
+		name:     name,
+		lookback: lookback,
+		horizon:  horizon,
+		alerter:  alerter,
+	}, nil
+}
+
 func toAlerter(alerter *core.AlerterConfig) (Alerter, error) {
 	if alerter.Prometheus != nil {
 		return &PrometheusAlerter{
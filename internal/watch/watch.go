@@ -0,0 +1,70 @@
+package watch
+
+import (
+	"context"
+	"maps"
+	"os"
+	"time"
+)
+
+// Watcher watches files by polling their modification times and sizes.
+type Watcher struct {
+	interval time.Duration
+	files    func() []string
+}
+
+// NewWatcher creates a watcher that polls the files returned by files at every interval.
+func NewWatcher(interval time.Duration, files func() []string) *Watcher {
+	return &Watcher{
+		interval: interval,
+		files:    files,
+	}
+}
+
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+// Watch calls onChange every time the watched files are created, modified or removed until ctx is done or onChange fails.
+func (w *Watcher) Watch(ctx context.Context, onChange func() error) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	states := w.poll()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		// The states polled before onChange are kept so that changes made while onChange runs are reported at the next poll.
+		// Files written by onChange (e.g., generated outputs) should not be watched.
+		newStates := w.poll()
+		if !maps.Equal(states, newStates) {
+			if err := onChange(); err != nil {
+				return err
+			}
+		}
+		states = newStates
+	}
+}
+
+func (w *Watcher) poll() map[string]fileState {
+	states := map[string]fileState{}
+	for _, file := range w.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			states[file] = fileState{}
+			continue
+		}
+		states[file] = fileState{
+			exists:  true,
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		}
+	}
+	return states
+}
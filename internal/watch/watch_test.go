@@ -0,0 +1,87 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "spec.yaml")
+	if err := os.WriteFile(file, []byte("a"), 0o644); err != nil {
+		t.Fatalf("failed to write a file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changed := make(chan struct{}, 1)
+	w := NewWatcher(10*time.Millisecond, func() []string { return []string{file} })
+	go w.Watch(ctx, func() error { changed <- struct{}{}; return nil })
+
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-changed:
+		t.Fatalf("onChange was called although the file was not changed")
+	default:
+	}
+
+	if err := os.WriteFile(file, []byte("ab"), 0o644); err != nil {
+		t.Fatalf("failed to write a file: %v", err)
+	}
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		t.Fatalf("onChange was not called after the file was changed")
+	}
+
+	if err := os.Remove(file); err != nil {
+		t.Fatalf("failed to remove a file: %v", err)
+	}
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		t.Fatalf("onChange was not called after the file was removed")
+	}
+}
+
+func TestWatcherChangeDuringOnChange(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "spec.yaml")
+	if err := os.WriteFile(file, []byte("a"), 0o644); err != nil {
+		t.Fatalf("failed to write a file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changed := make(chan struct{}, 2)
+	calls := 0
+	w := NewWatcher(10*time.Millisecond, func() []string { return []string{file} })
+	go w.Watch(ctx, func() error {
+		calls++
+		if calls == 1 {
+			// The file is edited again while the first change is being handled.
+			if err := os.WriteFile(file, []byte("abc"), 0o644); err != nil {
+				t.Errorf("failed to write a file: %v", err)
+			}
+		}
+		changed <- struct{}{}
+		return nil
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(file, []byte("ab"), 0o644); err != nil {
+		t.Fatalf("failed to write a file: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			t.Fatalf("onChange was called %d times although the file was changed during onChange", i)
+		}
+	}
+}
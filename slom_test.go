@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -39,12 +40,156 @@ func TestGenerateDocumentOutput(t *testing.T) {
 				args := []string{"generate", "document", "-o", "yaml", specFile}
 				checkSlomOutput(t, args, outFileDocumentYaml)
 			})
+			outFileDocumentMarkdown := filepath.Join(dir, "out/document-markdown", specId+".md")
+			runTestWithOutFile(t, outFileDocumentMarkdown, "document-markdown", func(t *testing.T) {
+				args := []string{"generate", "document", "-o", "markdown", specFile}
+				checkSlomOutput(t, args, outFileDocumentMarkdown)
+			})
+			outFileDocumentHTML := filepath.Join(dir, "out/document-html", specId+".html")
+			runTestWithOutFile(t, outFileDocumentHTML, "document-html", func(t *testing.T) {
+				args := []string{"generate", "document", "-o", "html", specFile}
+				checkSlomOutput(t, args, outFileDocumentHTML)
+			})
 			outFileDocumentGoTemplateFile := filepath.Join(dir, "out/document-go-template-file", specId+".md")
 			runTestWithOutFile(t, outFileDocumentGoTemplateFile, "document-go-template-file", func(t *testing.T) {
 				goTemplateFile := filepath.Join(dir, "go-template-file", specId+".tmpl")
 				args := []string{"generate", "document", "-o", "go-template-file=" + goTemplateFile, specFile}
 				checkSlomOutput(t, args, outFileDocumentGoTemplateFile)
 			})
+			outFileDocumentGoTemplateDir := filepath.Join(dir, "out/document-go-template-dir", specId+".md")
+			runTestWithOutFile(t, outFileDocumentGoTemplateDir, "document-go-template-dir", func(t *testing.T) {
+				goTemplateDir := filepath.Join(dir, "go-template-dir")
+				args := []string{"generate", "document", "-o", "go-template-dir=" + goTemplateDir, specFile}
+				checkSlomOutput(t, args, outFileDocumentGoTemplateDir)
+			})
+		})
+	}
+}
+
+func TestRenderOutput(t *testing.T) {
+	dir := "testdata/render-output"
+
+	specFilesPattern := filepath.Join(dir, "spec/*.yaml")
+	specFiles, err := filepath.Glob(specFilesPattern)
+	if err != nil {
+		t.Fatalf("failed to look up spec files %s: %s", specFilesPattern, err)
+	}
+
+	for _, specFile := range specFiles {
+		specId := filepath.Base(specFile[:len(specFile)-len(filepath.Ext(specFile))])
+		overlayFile := filepath.Join(dir, "overlay", specId+".yaml")
+		specArgs := []string{"--overlay", overlayFile, "--var", "JOB=api", "--var", "ENV=production", "--var", "RATIO=0.999", specFile}
+
+		t.Run(specId, func(t *testing.T) {
+			outFileRender := filepath.Join(dir, "out/render", specId+".yaml")
+			runTestWithOutFile(t, outFileRender, "render", func(t *testing.T) {
+				args := append([]string{"render"}, specArgs...)
+				checkSlomOutput(t, args, outFileRender)
+			})
+			outFilePrometheusRule := filepath.Join(dir, "out/prometheus-rule", specId+".yaml")
+			runTestWithOutFile(t, outFilePrometheusRule, "prometheus-rule", func(t *testing.T) {
+				args := append([]string{"generate", "prometheus-rule"}, specArgs...)
+				checkSlomOutput(t, args, outFilePrometheusRule)
+			})
+		})
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	dir := "testdata/render-error"
+	specDir := filepath.Join(dir, "spec")
+	libDir := filepath.Join(dir, "lib")
+
+	testCases := []struct {
+		specId   string
+		expected string
+	}{
+		{
+			specId: "cycle",
+			expected: fmt.Sprintf(
+				`%s:4: failed to include "../lib/cycle-a.yaml": %s:3: failed to include "cycle-b.yaml": %s:3: failed to include "cycle-a.yaml": cycle detected: %s -> %s -> %s -> %s`,
+				filepath.Join(specDir, "cycle.yaml"),
+				filepath.Join(libDir, "cycle-a.yaml"),
+				filepath.Join(libDir, "cycle-b.yaml"),
+				filepath.Join(specDir, "cycle.yaml"),
+				filepath.Join(libDir, "cycle-a.yaml"),
+				filepath.Join(libDir, "cycle-b.yaml"),
+				filepath.Join(libDir, "cycle-a.yaml"),
+			),
+		},
+		{
+			specId: "broken-ref",
+			expected: fmt.Sprintf(
+				`%s:9: failed to resolve $ref "../lib/indicators.yaml#/indicators/http-latency": %s: pointer "/indicators/http-latency" not found: no key "http-latency"`,
+				filepath.Join(specDir, "broken-ref.yaml"),
+				filepath.Join(libDir, "indicators.yaml"),
+			),
+		},
+		{
+			// The version is checked before the variables are substituted.
+			specId: "unsupported-version",
+			expected: fmt.Sprintf(
+				`failed to load %s as spec config file: unsupported spec config version "v1". Supported versions are v1alpha`,
+				filepath.Join(specDir, "unsupported-version.yaml"),
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.specId, func(t *testing.T) {
+			specFile := filepath.Join(specDir, tc.specId+".yaml")
+			stdout := bytes.Buffer{}
+			stderr := bytes.Buffer{}
+			err := run([]string{"render", specFile}, &stdout, &stderr)
+			if err == nil {
+				t.Fatalf("expected an error but got none:\n%s", stdout.String())
+			}
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q but got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestMigrateOutput(t *testing.T) {
+	dir := "testdata/migrate-output"
+
+	specFilesPattern := filepath.Join(dir, "spec/*.yaml")
+	specFiles, err := filepath.Glob(specFilesPattern)
+	if err != nil {
+		t.Fatalf("failed to look up spec files %s: %s", specFilesPattern, err)
+	}
+
+	for _, specFile := range specFiles {
+		specId := filepath.Base(specFile[:len(specFile)-len(filepath.Ext(specFile))])
+
+		t.Run(specId, func(t *testing.T) {
+			outFileMigrate := filepath.Join(dir, "out/migrate", specId+".yaml")
+			runTestWithOutFile(t, outFileMigrate, "migrate", func(t *testing.T) {
+				args := []string{"migrate", specFile}
+				checkSlomOutput(t, args, outFileMigrate)
+			})
+		})
+	}
+}
+
+func TestGenerateCatalogOutput(t *testing.T) {
+	dir := "testdata/generate-catalog-output"
+	specDir := filepath.Join(dir, "spec")
+
+	for _, tc := range []struct {
+		output string
+		ext    string
+	}{
+		{"json", ".json"},
+		{"yaml", ".yaml"},
+		{"markdown", ".md"},
+		{"html", ".html"},
+	} {
+		outFile := filepath.Join(dir, "out", "catalog"+tc.ext)
+		runTestWithOutFile(t, outFile, "catalog-"+tc.output, func(t *testing.T) {
+			args := []string{"generate", "catalog", "-o", tc.output, specDir}
+			checkSlomOutput(t, args, outFile)
 		})
 	}
 }
@@ -73,6 +218,153 @@ func TestGeneratePrometheusRuleOutput(t *testing.T) {
 				args := []string{"generate", "prometheus-rule", "-o", "json", specFile}
 				checkSlomOutput(t, args, outFilePrometheusRuleJson)
 			})
+
+			outFilePrometheusRuleRecordRatios := filepath.Join(dir, "out/prometheus-rule-record-ratios", specId+".yaml")
+			runTestWithOutFile(t, outFilePrometheusRuleRecordRatios, "prometheus-rule-record-ratios", func(t *testing.T) {
+				args := []string{"generate", "prometheus-rule", "-o", "prometheus", "--record-burn-rate", "--record-success-ratio", specFile}
+				checkSlomOutput(t, args, outFilePrometheusRuleRecordRatios)
+			})
+
+			outFilePrometheusRuleAggregateWindows := filepath.Join(dir, "out/prometheus-rule-aggregate-windows", specId+".yaml")
+			runTestWithOutFile(t, outFilePrometheusRuleAggregateWindows, "prometheus-rule-aggregate-windows", func(t *testing.T) {
+				args := []string{"generate", "prometheus-rule", "-o", "prometheus", "--aggregate-windows", specFile}
+				checkSlomOutput(t, args, outFilePrometheusRuleAggregateWindows)
+			})
+
+			outFilePrometheusRuleShareRules := filepath.Join(dir, "out/prometheus-rule-share-rules", specId+".json")
+			runTestWithOutFile(t, outFilePrometheusRuleShareRules, "prometheus-rule-share-rules", func(t *testing.T) {
+				args := []string{"generate", "prometheus-rule", "-o", "json", "--share-rules", specFile}
+				checkSlomOutput(t, args, outFilePrometheusRuleShareRules)
+			})
+
+			outFilePrometheusRuleGoTemplateFile := filepath.Join(dir, "out/prometheus-rule-go-template-file", specId+".txt")
+			runTestWithOutFile(t, outFilePrometheusRuleGoTemplateFile, "prometheus-rule-go-template-file", func(t *testing.T) {
+				goTemplateFile := filepath.Join(dir, "go-template-file/rules.tmpl")
+				args := []string{"generate", "prometheus-rule", "-o", "go-template-file=" + goTemplateFile, specFile}
+				checkSlomOutput(t, args, outFilePrometheusRuleGoTemplateFile)
+			})
+		})
+	}
+}
+
+func TestGenerateGrafanaDashboardOutput(t *testing.T) {
+	dir := "testdata/generate-grafana-dashboard-output"
+
+	specFilesPattern := filepath.Join(dir, "spec/*.yaml")
+	specFiles, err := filepath.Glob(specFilesPattern)
+	if err != nil {
+		t.Fatalf("failed to look up spec files %s: %s", specFilesPattern, err)
+	}
+
+	for _, specFile := range specFiles {
+		specId := filepath.Base(specFile[:len(specFile)-len(filepath.Ext(specFile))])
+
+		t.Run(specId, func(t *testing.T) {
+			outFileGrafanaDashboardJson := filepath.Join(dir, "out/grafana-dashboard-json", specId+".json")
+			runTestWithOutFile(t, outFileGrafanaDashboardJson, "grafana-dashboard-json", func(t *testing.T) {
+				args := []string{"generate", "grafana-dashboard", "-o", "json", specFile}
+				checkSlomOutput(t, args, outFileGrafanaDashboardJson)
+			})
+
+			outFileGrafanaDashboardShareRules := filepath.Join(dir, "out/grafana-dashboard-share-rules", specId+".json")
+			runTestWithOutFile(t, outFileGrafanaDashboardShareRules, "grafana-dashboard-share-rules", func(t *testing.T) {
+				args := []string{"generate", "grafana-dashboard", "-o", "json", "--share-rules", specFile}
+				checkSlomOutput(t, args, outFileGrafanaDashboardShareRules)
+			})
+		})
+	}
+}
+
+func TestGenerateAlertmanagerOutput(t *testing.T) {
+	dir := "testdata/generate-alertmanager-output"
+
+	specFilesPattern := filepath.Join(dir, "spec/*.yaml")
+	specFiles, err := filepath.Glob(specFilesPattern)
+	if err != nil {
+		t.Fatalf("failed to look up spec files %s: %s", specFilesPattern, err)
+	}
+
+	for _, specFile := range specFiles {
+		specId := filepath.Base(specFile[:len(specFile)-len(filepath.Ext(specFile))])
+
+		t.Run(specId, func(t *testing.T) {
+			outFileAlertmanagerYaml := filepath.Join(dir, "out/alertmanager-yaml", specId+".yaml")
+			runTestWithOutFile(t, outFileAlertmanagerYaml, "alertmanager-yaml", func(t *testing.T) {
+				args := []string{
+					"generate", "alertmanager", "-o", "yaml",
+					"--default-receiver", "slack",
+					"--receiver", "page=pagerduty",
+					"--receiver", "ticket=jira",
+					specFile,
+				}
+				checkSlomOutput(t, args, outFileAlertmanagerYaml)
+			})
+		})
+	}
+}
+
+func TestGenerateGrafanaSLOOutput(t *testing.T) {
+	dir := "testdata/generate-grafana-slo-output"
+
+	specFilesPattern := filepath.Join(dir, "spec/*.yaml")
+	specFiles, err := filepath.Glob(specFilesPattern)
+	if err != nil {
+		t.Fatalf("failed to look up spec files %s: %s", specFilesPattern, err)
+	}
+
+	for _, specFile := range specFiles {
+		specId := filepath.Base(specFile[:len(specFile)-len(filepath.Ext(specFile))])
+
+		t.Run(specId, func(t *testing.T) {
+			outFileGrafanaSLOJson := filepath.Join(dir, "out/grafana-slo-json", specId+".json")
+			runTestWithOutFile(t, outFileGrafanaSLOJson, "grafana-slo-json", func(t *testing.T) {
+				args := []string{"generate", "grafana-slo", "-o", "json", specFile}
+				checkSlomOutput(t, args, outFileGrafanaSLOJson)
+			})
+		})
+	}
+}
+
+func TestGenerateDatadogSLOOutput(t *testing.T) {
+	dir := "testdata/generate-datadog-slo-output"
+
+	specFilesPattern := filepath.Join(dir, "spec/*.yaml")
+	specFiles, err := filepath.Glob(specFilesPattern)
+	if err != nil {
+		t.Fatalf("failed to look up spec files %s: %s", specFilesPattern, err)
+	}
+
+	for _, specFile := range specFiles {
+		specId := filepath.Base(specFile[:len(specFile)-len(filepath.Ext(specFile))])
+
+		t.Run(specId, func(t *testing.T) {
+			outFileDatadogSLOJson := filepath.Join(dir, "out/datadog-slo-json", specId+".json")
+			runTestWithOutFile(t, outFileDatadogSLOJson, "datadog-slo-json", func(t *testing.T) {
+				args := []string{"generate", "datadog-slo", "-o", "json", specFile}
+				checkSlomOutput(t, args, outFileDatadogSLOJson)
+			})
+		})
+	}
+}
+
+func TestGenerateGoogleCloudMonitoringSLOOutput(t *testing.T) {
+	dir := "testdata/generate-google-cloud-monitoring-slo-output"
+
+	specFilesPattern := filepath.Join(dir, "spec/*.yaml")
+	specFiles, err := filepath.Glob(specFilesPattern)
+	if err != nil {
+		t.Fatalf("failed to look up spec files %s: %s", specFilesPattern, err)
+	}
+
+	for _, specFile := range specFiles {
+		specId := filepath.Base(specFile[:len(specFile)-len(filepath.Ext(specFile))])
+
+		t.Run(specId, func(t *testing.T) {
+			outFileGoogleCloudMonitoringSLOJson := filepath.Join(dir, "out/google-cloud-monitoring-slo-json", specId+".json")
+			runTestWithOutFile(t, outFileGoogleCloudMonitoringSLOJson, "google-cloud-monitoring-slo-json", func(t *testing.T) {
+				args := []string{"generate", "google-cloud-monitoring-slo", "-o", "json", specFile}
+				checkSlomOutput(t, args, outFileGoogleCloudMonitoringSLOJson)
+			})
 		})
 	}
 }
@@ -101,6 +393,13 @@ func TestGeneratePrometheusSeriesOutput(t *testing.T) {
 				args := []string{"generate", "prometheus-series", "-o", "unittest", seriesFile}
 				checkSlomOutput(t, args, outFilePrometheusSeriesUnitTest)
 			})
+
+			outFilePrometheusSeriesGoTemplateFile := filepath.Join(dir, "out/prometheus-series-go-template-file", seriesId+".csv")
+			runTestWithOutFile(t, outFilePrometheusSeriesGoTemplateFile, "prometheus-series-go-template-file", func(t *testing.T) {
+				goTemplateFile := filepath.Join(dir, "go-template-file/series.tmpl")
+				args := []string{"generate", "prometheus-series", "-o", "go-template-file=" + goTemplateFile, seriesFile}
+				checkSlomOutput(t, args, outFilePrometheusSeriesGoTemplateFile)
+			})
 		})
 	}
 }
@@ -218,3 +517,112 @@ func checkPromtool(t *testing.T, args []string, unitTestFileName string, specCon
 
 	t.Cleanup(func() { container.Terminate(ctx) })
 }
+
+func TestBuild(t *testing.T) {
+	dir := "testdata/build"
+	t.Setenv("REGION", "asia")
+
+	projectDir := t.TempDir()
+	if err := os.CopyFS(projectDir, os.DirFS(filepath.Join(dir, "project"))); err != nil {
+		t.Fatalf("failed to copy the project: %v", err)
+	}
+	projectFile := filepath.Join(projectDir, "slom.yaml")
+
+	t.Run("build", func(t *testing.T) {
+		stdout := bytes.Buffer{}
+		stderr := bytes.Buffer{}
+		if err := run([]string{"build", "-f", projectFile}, &stdout, &stderr); err != nil {
+			t.Fatalf("failed to run: %v\n%s", err, stdout.String())
+		}
+
+		outFilesPattern := filepath.Join(dir, "out/*/*")
+		outFiles, err := filepath.Glob(outFilesPattern)
+		if err != nil {
+			t.Fatalf("failed to look up output files %s: %s", outFilesPattern, err)
+		}
+		for _, outFile := range outFiles {
+			rel, _ := filepath.Rel(dir, outFile)
+			expected, err := os.ReadFile(outFile)
+			if err != nil {
+				t.Fatalf("failed to load a file %s: %v", outFile, err)
+			}
+			actual, err := os.ReadFile(filepath.Join(projectDir, rel))
+			if err != nil {
+				t.Fatalf("failed to load a built file %s: %v", rel, err)
+			}
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("built file %s does not match the expected content. %s", rel, cmp.Diff(expected, actual))
+			}
+		}
+	})
+
+	t.Run("rebuild", func(t *testing.T) {
+		stdout := bytes.Buffer{}
+		stderr := bytes.Buffer{}
+		if err := run([]string{"build", "-f", projectFile}, &stdout, &stderr); err != nil {
+			t.Fatalf("failed to run: %v\n%s", err, stdout.String())
+		}
+		if bytes.Contains(stdout.Bytes(), []byte("built")) {
+			t.Errorf("outputs were rebuilt although their inputs haven't changed:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("rebuild-changed", func(t *testing.T) {
+		templateFile, err := os.OpenFile(filepath.Join(projectDir, "templates/document.md.tmpl"), os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatalf("failed to open the template file: %v", err)
+		}
+		templateFile.WriteString("\n")
+		templateFile.Close()
+
+		stdout := bytes.Buffer{}
+		stderr := bytes.Buffer{}
+		if err := run([]string{"build", "-f", projectFile}, &stdout, &stderr); err != nil {
+			t.Fatalf("failed to run: %v\n%s", err, stdout.String())
+		}
+		for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+			built := strings.HasPrefix(line, "built")
+			if documents := strings.Contains(line, "documents:"); built != documents {
+				t.Errorf("only documents should be rebuilt after the template changed: %s", line)
+			}
+		}
+	})
+
+	t.Run("rebuild-env-changed", func(t *testing.T) {
+		t.Setenv("REGION", "europe")
+
+		stdout := bytes.Buffer{}
+		stderr := bytes.Buffer{}
+		if err := run([]string{"build", "-f", projectFile}, &stdout, &stderr); err != nil {
+			t.Fatalf("failed to run: %v\n%s", err, stdout.String())
+		}
+		for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+			built := strings.HasPrefix(line, "built")
+			if production := strings.Contains(line, "rules-production:"); built != production {
+				t.Errorf("only rules-production should be rebuilt after the environment variable changed: %s", line)
+			}
+		}
+	})
+
+	t.Run("slo-id-conflict", func(t *testing.T) {
+		specFile := filepath.Join(projectDir, "spec/simple2.yaml")
+		content, err := os.ReadFile(filepath.Join(projectDir, "spec/simple.yaml"))
+		if err != nil {
+			t.Fatalf("failed to read the spec file: %v", err)
+		}
+		if err := os.WriteFile(specFile, content, 0o644); err != nil {
+			t.Fatalf("failed to write the spec file: %v", err)
+		}
+		defer os.Remove(specFile)
+
+		stdout := bytes.Buffer{}
+		stderr := bytes.Buffer{}
+		if err := run([]string{"build", "-f", projectFile}, &stdout, &stderr); err == nil {
+			t.Fatalf("build succeeded although the SLO IDs conflict:\n%s", stdout.String())
+		}
+		expected := `SLO ID "test-availability" is also used in spec/simple.yaml`
+		if !strings.Contains(stdout.String(), expected) {
+			t.Errorf("expected the output to contain %q:\n%s", expected, stdout.String())
+		}
+	})
+}